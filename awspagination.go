@@ -1,11 +1,17 @@
 package awspagination
 
 import (
+	"fmt"
 	"go/ast"
+	"go/token"
 	"go/types"
+	"io"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
 	"strings"
 
-	"github.com/golangci/plugin-module-register/register"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
 	"golang.org/x/tools/go/ast/inspector"
@@ -13,8 +19,8 @@ import (
 
 const Doc = `check for missing pagination handling in AWS SDK List API calls
 
-This linter detects calls to AWS SDK v2 List APIs that return pagination tokens
-(NextToken, NextMarker, NextContinuationToken, etc.) but don't implement pagination handling.`
+This linter detects calls to AWS SDK for Go (v2 and v1) List APIs that return pagination
+tokens (NextToken, NextMarker, NextContinuationToken, etc.) but don't implement pagination handling.`
 
 // Default pagination token field names used across AWS services
 var defaultPaginationTokenFields = []string{
@@ -38,6 +44,9 @@ var defaultPaginationTokenFields = []string{
 // 1. Add an entry to this map with the service name and field names
 // 2. Add test cases in testdata/src/test/<service>.go
 // 3. Update README.md to document the new support
+//
+// Users can add services or fields without changing this map through
+// Config.ServiceTokenFields, see getServiceTokenFields.
 var apiSpecificPaginationFields = map[string][]string{
 	"dynamodb":   {"LastEvaluatedKey"},                                                        // map[string]types.AttributeValue
 	"apigateway": {"Position"},                                                                // *string
@@ -45,10 +54,8 @@ var apiSpecificPaginationFields = map[string][]string{
 }
 
 // Config holds the configuration for the analyzer.
-// This type is exported to support future golangci-lint module plugin integration,
-// where settings are decoded from YAML using mapstructure (which requires exported fields).
-// For current direct integration via Analyzer.Flags, the export is not strictly necessary,
-// but maintaining it provides forward compatibility.
+// Each analyzer created by NewAnalyzer owns a copy of its Config, so analyzers with
+// different configurations can run in the same process without interfering.
 type Config struct {
 	// CustomTokenFields are additional pagination token field names to check.
 	// These are added to the default fields, not replacing them.
@@ -57,6 +64,61 @@ type Config struct {
 	// IncludeTests determines whether to analyze test files (*_test.go).
 	// Default is false (test files are excluded from analysis).
 	IncludeTests bool
+
+	// IncludeGenerated determines whether to analyze generated files, which carry a
+	// "// Code generated ... DO NOT EDIT." header.
+	// Default is false (generated files are excluded from analysis).
+	IncludeGenerated bool
+
+	// ExcludePaths are glob patterns of files excluded from analysis
+	// (e.g., "internal/mocks/**", "**/zz_generated*.go"), see matchesPathPattern.
+	ExcludePaths stringSliceFlag
+
+	// StrictLoops determines whether manual pagination loops are validated.
+	// When enabled, reading a pagination token is only accepted if the call is made
+	// in a loop that feeds the token back into the input and stops on an empty token.
+	// Default is false (any read of a pagination token counts as handling).
+	StrictLoops bool
+
+	// StrictTokenUse determines whether only meaningful reads of a pagination token count
+	// as handling: reads that control the flow (e.g., if out.NextToken == nil) or that
+	// store the token (e.g., input.NextToken = out.NextToken), but not reads that are only
+	// logged or printed, even through local variables, or assigned to the blank identifier.
+	// See meaningfulTokenRead.
+	// Default is false (any read of a pagination token counts as handling).
+	StrictTokenUse bool
+
+	// LimitedCalls decides how calls intentionally limited to a single page are handled:
+	// calls whose input explicitly sets a page-size field (e.g., MaxResults) and whose
+	// result is only used locally. One of "report", "info" or "ignore".
+	// Default is "report" (limited calls are reported like any other call).
+	LimitedCalls limitPolicy
+
+	// ServiceTokenFields are service-specific pagination field names, keyed by the
+	// service package name (e.g., "cloudwatchlogs": ["NextForwardToken"]). They are merged
+	// into the built-in service-specific fields: an entry adds a service or adds fields to
+	// the built-in fields of that service.
+	ServiceTokenFields serviceFieldsFlag
+
+	// ExcludeOperations are patterns of operations that are not checked, such as
+	// operations known to return bounded results (e.g., "ecs.ListClusters", "iam.List*").
+	// See matchesOperation for the pattern syntax.
+	ExcludeOperations stringSliceFlag
+
+	// IncludeOperations are patterns of operations that are always checked: they take
+	// precedence over ExcludeOperations, and calls of them are reported even when
+	// intentionally limited (see LimitedCalls).
+	IncludeOperations stringSliceFlag
+
+	// Explain determines whether the decision about each candidate List API call is
+	// written to stderr: the facts it is based on (result type, service, token fields)
+	// and the AST node taken as evidence of pagination handling, if any.
+	// Default is false.
+	Explain bool
+
+	// explainOutput is where decisions are written when Explain is set (os.Stderr unless
+	// set by tests).
+	explainOutput io.Writer
 }
 
 // stringSliceFlag implements flag.Value interface for comma-separated string slice flags.
@@ -75,6 +137,65 @@ func (s *stringSliceFlag) Set(value string) error {
 	return nil
 }
 
+// serviceFieldsFlag implements flag.Value interface for repeated service:Field flags,
+// mapping lowercase service names to their pagination field names.
+type serviceFieldsFlag map[string][]string
+
+func (s *serviceFieldsFlag) String() string {
+	entries := make([]string, 0, len(*s))
+	for _, service := range slices.Sorted(maps.Keys(*s)) {
+		entries = append(entries, service+":"+strings.Join((*s)[service], ","))
+	}
+	return strings.Join(entries, " ")
+}
+
+// Set adds the fields of a "service:Field[,Field...]" value (e.g., cloudwatchlogs:NextForwardToken).
+func (s *serviceFieldsFlag) Set(value string) error {
+	service, fields, ok := strings.Cut(value, ":")
+	if !ok {
+		return fmt.Errorf("invalid service field %q: must be service:Field", value)
+	}
+	return s.add(service, strings.Split(fields, ","))
+}
+
+// add adds fields to the service-specific fields of service, validating both names.
+func (s *serviceFieldsFlag) add(service string, fields []string) error {
+	service = strings.ToLower(strings.TrimSpace(service))
+	if service == "" {
+		return fmt.Errorf("invalid service fields %v: service name is empty", fields)
+	}
+	for _, field := range fields {
+		if !token.IsIdentifier(field) {
+			return fmt.Errorf("invalid pagination field %q of service %q: must be a field name", field, service)
+		}
+	}
+	if len(fields) == 0 {
+		return fmt.Errorf("no pagination fields given for service %q", service)
+	}
+
+	if *s == nil {
+		*s = make(serviceFieldsFlag)
+	}
+	for _, field := range fields {
+		if !slices.Contains((*s)[service], field) {
+			(*s)[service] = append((*s)[service], field)
+		}
+	}
+	return nil
+}
+
+// clone returns a deep copy of s, so that adding fields to the copy leaves s untouched.
+func (s serviceFieldsFlag) clone() serviceFieldsFlag {
+	if s == nil {
+		return nil
+	}
+	cloned := make(serviceFieldsFlag, len(s))
+	for service, fields := range s {
+		cloned[service] = slices.Clone(fields)
+	}
+	return cloned
+}
+
 // Settings holds the configuration for golangci-lint module plugin integration.
 // This struct is used when the analyzer is loaded as a module plugin, where
 // settings are decoded from YAML configuration files using mapstructure.
@@ -89,127 +210,257 @@ type Settings struct {
 	// Default is false (test files are excluded from analysis).
 	// Example YAML: include-tests: true
 	IncludeTests bool `json:"include-tests" mapstructure:"include-tests"`
-}
 
-// config is the package-level configuration instance populated via command-line flags.
-// Note: This variable is mutable and shared across analyzer invocations.
-// In test environments with concurrent execution, tests should restore the original
-// config in defer blocks to avoid side effects.
-var config Config
+	// IncludeGenerated determines whether to analyze generated files.
+	// Default is false (generated files are excluded from analysis).
+	// Example YAML: include-generated: true
+	IncludeGenerated bool `json:"include-generated" mapstructure:"include-generated"`
+
+	// ExcludePaths are glob patterns of files excluded from analysis.
+	// Example YAML: exclude-paths: ["internal/mocks/**", "**/zz_generated*.go"]
+	ExcludePaths []string `json:"exclude-paths" mapstructure:"exclude-paths"`
+
+	// StrictLoops determines whether manual pagination loops are validated.
+	// Default is false (any read of a pagination token counts as handling).
+	// Example YAML: strict-loops: true
+	StrictLoops bool `json:"strict-loops" mapstructure:"strict-loops"`
+
+	// StrictTokenUse determines whether only meaningful reads of a pagination token count
+	// as handling, ignoring reads that are only logged or printed.
+	// Default is false (any read of a pagination token counts as handling).
+	// Example YAML: strict-token-use: true
+	StrictTokenUse bool `json:"strict-token-use" mapstructure:"strict-token-use"`
+
+	// LimitedCalls decides how calls intentionally limited to a single page are handled.
+	// One of "report", "info" or "ignore". Default is "report".
+	// Example YAML: limited-calls: info
+	LimitedCalls string `json:"limited-calls" mapstructure:"limited-calls"`
+
+	// ServiceFields are service-specific pagination field names, keyed by service name.
+	// An entry adds a service or adds fields to the built-in fields of the service.
+	// Example YAML: service-fields: {cloudwatchlogs: ["NextForwardToken"]}
+	ServiceFields map[string][]string `json:"service-fields" mapstructure:"service-fields"`
+
+	// ExcludeOperations are patterns of operations that are not checked.
+	// Example YAML: exclude-operations: ["ecs.ListClusters", "iam.List*"]
+	ExcludeOperations []string `json:"exclude-operations" mapstructure:"exclude-operations"`
+
+	// IncludeOperations are patterns of operations that are always checked, taking
+	// precedence over exclude-operations and limited-calls.
+	// Example YAML: include-operations: ["s3.ListObjectsV2"]
+	IncludeOperations []string `json:"include-operations" mapstructure:"include-operations"`
+
+	// Explain determines whether the decision about each candidate call is written to stderr.
+	// Default is false.
+	// Example YAML: explain: true
+	Explain bool `json:"explain" mapstructure:"explain"`
+}
 
-// Analyzer is the awspagination analyzer.
+// Analyzer is the awspagination analyzer with the default configuration, adjustable
+// through its flags.
 // It can be used standalone or integrated into golangci-lint.
 //
 // For golangci-lint integration, this analyzer requires LoadModeTypesInfo
 // because it uses pass.TypesInfo to check types.
-var Analyzer = &analysis.Analyzer{
-	Name:     "awspagination",
-	Doc:      Doc,
-	Run:      run,
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
-}
+var Analyzer = NewAnalyzer(Config{})
+
+// NewAnalyzer creates an analyzer checking with cfg.
+// The analyzer closes over its own copy of cfg: its flags only change that copy, so
+// analyzers created separately are independent of each other.
+func NewAnalyzer(cfg Config) *analysis.Analyzer {
+	cfg.CustomTokenFields = slices.Clone(cfg.CustomTokenFields)
+	cfg.ServiceTokenFields = cfg.ServiceTokenFields.clone()
+	cfg.ExcludePaths = slices.Clone(cfg.ExcludePaths)
+	cfg.ExcludeOperations = slices.Clone(cfg.ExcludeOperations)
+	cfg.IncludeOperations = slices.Clone(cfg.IncludeOperations)
+	if cfg.explainOutput == nil {
+		cfg.explainOutput = os.Stderr
+	}
+	cfg.explainOutput = &lockedWriter{w: cfg.explainOutput}
+
+	analyzer := &analysis.Analyzer{
+		Name: "awspagination",
+		Doc:  Doc,
+		Run: func(pass *analysis.Pass) (any, error) {
+			return run(pass, &cfg)
+		},
+		Requires:   []*analysis.Analyzer{inspect.Analyzer},
+		ResultType: reflect.TypeFor[*Result](),
+		FactTypes:  []analysis.Fact{new(paginationHandlerFact), new(paginationObligationFact)},
+	}
 
-func init() {
-	Analyzer.Flags.Var(&config.CustomTokenFields, "custom-fields",
+	analyzer.Flags.Var(&cfg.CustomTokenFields, "custom-fields",
 		"comma-separated list of custom pagination token field names (in addition to default fields)")
-	Analyzer.Flags.BoolVar(&config.IncludeTests, "include-tests", false,
+	analyzer.Flags.BoolVar(&cfg.IncludeTests, "include-tests", cfg.IncludeTests,
 		"analyze test files (*_test.go) in addition to regular source files (default: false)")
-}
-
-// New creates a new analyzer instance for golangci-lint module plugin integration.
-// This function is called when the analyzer is loaded as a module plugin.
-// It decodes settings from YAML configuration and applies them to the analyzer.
-//
-// For direct integration via Analyzer.Flags, this function is not used.
-// The two integration methods are mutually exclusive:
-// - Module plugin: Uses this New function with Settings from YAML
-// - Direct integration: Uses Analyzer.Flags with command-line flags
-//
-// Example YAML configuration:
-//
-//	linters-settings:
-//	  custom:
-//	    awspagination:
-//	      type: "module"
-//	      settings:
-//	        custom-fields: ["MyToken", "CustomNextToken"]
-//	        include-tests: true
-func New(settings any) ([]*analysis.Analyzer, error) {
-	s, err := register.DecodeSettings[Settings](settings)
-	if err != nil {
-		return nil, err
-	}
-
-	// Apply settings to the package-level config
-	// Convert []string to stringSliceFlag
-	config.CustomTokenFields = stringSliceFlag(s.CustomFields)
-	config.IncludeTests = s.IncludeTests
-
-	return []*analysis.Analyzer{Analyzer}, nil
+	analyzer.Flags.BoolVar(&cfg.IncludeGenerated, "include-generated", cfg.IncludeGenerated,
+		"analyze generated files (with a \"Code generated ... DO NOT EDIT.\" header) (default: false)")
+	analyzer.Flags.Var(&cfg.ExcludePaths, "exclude-paths",
+		"comma-separated list of glob patterns of files to exclude (e.g., internal/mocks/**,**/zz_generated*.go)")
+	analyzer.Flags.BoolVar(&cfg.StrictLoops, "strict-loops", cfg.StrictLoops,
+		"require manual pagination loops to feed the token back into the input and stop on an empty token (default: false)")
+	analyzer.Flags.BoolVar(&cfg.StrictTokenUse, "strict-token-use", cfg.StrictTokenUse,
+		"only count reads of a pagination token that control the flow or store the token as handling, not reads that are only logged (default: false)")
+	analyzer.Flags.Var(&cfg.LimitedCalls, "limited-calls",
+		"how to handle calls limited to a single page by a page-size field such as MaxResults: report, info or ignore (default: report)")
+	analyzer.Flags.Var(&cfg.ServiceTokenFields, "service-field",
+		"service-specific pagination field as service:Field (e.g., cloudwatchlogs:NextForwardToken), in addition to the built-in fields of the service; can be repeated")
+	analyzer.Flags.Var(&cfg.ExcludeOperations, "exclude-operations",
+		"comma-separated list of operations not to check, as service.Operation or output type patterns (e.g., ecs.ListClusters,iam.List*)")
+	analyzer.Flags.Var(&cfg.IncludeOperations, "include-operations",
+		"comma-separated list of operations to always check, overriding -exclude-operations and -limited-calls")
+	analyzer.Flags.BoolVar(&cfg.Explain, "explain", cfg.Explain,
+		"write to stderr why each candidate List API call is or is not reported (default: false)")
+
+	return analyzer
 }
 
 // getPaginationTokenFields returns all pagination token fields to check.
 // Returns a new slice containing default fields plus any custom fields
-// configured via the -custom-fields flag.
+// configured in cfg (e.g., via the -custom-fields flag).
 // The returned slice is a copy to prevent modification of the default field list.
-func getPaginationTokenFields() []string {
+func getPaginationTokenFields(cfg *Config) []string {
 	fields := make([]string, len(defaultPaginationTokenFields))
 	copy(fields, defaultPaginationTokenFields)
-	fields = append(fields, config.CustomTokenFields...)
+	fields = append(fields, cfg.CustomTokenFields...)
 	return fields
 }
 
-func run(pass *analysis.Pass) (any, error) {
+// getServiceTokenFields returns the service-specific pagination fields of serviceName:
+// the built-in fields of apiSpecificPaginationFields followed by the fields configured
+// in cfg, without duplicates.
+// The returned slice is a copy to prevent modification of the built-in field lists.
+func getServiceTokenFields(cfg *Config, serviceName string) ([]string, bool) {
+	serviceName = strings.ToLower(serviceName)
+	builtin, builtinOK := apiSpecificPaginationFields[serviceName]
+	configured, configuredOK := cfg.ServiceTokenFields[serviceName]
+	if !builtinOK && !configuredOK {
+		return nil, false
+	}
+
+	fields := slices.Clone(builtin)
+	for _, field := range configured {
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields, true
+}
+
+func run(pass *analysis.Pass, cfg *Config) (any, error) {
+	// Patterns given via flags are only known once the analyzer runs
+	for _, patterns := range [][]string{cfg.ExcludeOperations, cfg.IncludeOperations} {
+		if err := validateOperationPatterns(patterns); err != nil {
+			return nil, err
+		}
+	}
+	if err := validatePathPatterns(cfg.ExcludePaths); err != nil {
+		return nil, err
+	}
+
 	inspector := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 
-	// Use inspector.Nodes for efficient traversal with context tracking
-	// This is more efficient than using ast.Inspect inside inspector.Preorder
+	// Test files, generated files and excluded paths are skipped
+	skipped := skippedFiles(pass, cfg)
+	isSkipped := func(n ast.Node) bool {
+		return skipped[pass.Fset.File(n.Pos()).Name()]
+	}
+
+	// The fingerprints of the diagnostics left after suppression are the result, including
+	// the diagnostics of the directives themselves
+	result := &Result{}
+	recording := recordFindings(pass, cfg, result)
+
+	// Diagnostics suppressed by //awspagination:ignore directives are dropped by the
+	// filtered pass, and directives left unused are reported once all checks are done
+	var files []*ast.File
+	for _, file := range pass.Files {
+		if !isSkipped(file) {
+			files = append(files, file)
+		}
+	}
+	directives := parseIgnoreDirectives(recording, files)
+	pass = directives.filter(recording)
+
+	// Call sites are recorded as they are checked, and their diagnostics are observed
+	// before suppression to tell unhandled calls from suppressed ones
+	inv := newInventory(cfg, directives)
+	defer func() { directives.reportUnused(recording, inv.flagGated()) }()
+	pass = inv.observe(pass)
+
+	// Export facts for helper functions that handle pagination of their parameters
+	// before checking call sites, so that passing a result to a helper declared
+	// anywhere in the package counts as pagination handling
+	var funcDecls []*ast.FuncDecl
+	inspector.Preorder([]ast.Node{(*ast.FuncDecl)(nil)}, func(n ast.Node) {
+		if isSkipped(n) {
+			return
+		}
+		funcDecls = append(funcDecls, n.(*ast.FuncDecl))
+	})
+	exportPaginationHandlerFacts(pass, cfg, funcDecls)
+
+	// inspector.WithStack is more efficient than ast.Inspect (~2.5x faster)
+	// and provides the enclosing nodes of each visited node, which gives us:
+	// - The enclosing function, whose body is searched for pagination handling
+	// - The parent of each call, which decides how its result is consumed
 	nodeFilter := []ast.Node{
-		(*ast.FuncDecl)(nil),
 		(*ast.AssignStmt)(nil),
+		(*ast.ValueSpec)(nil),
+		(*ast.CallExpr)(nil),
 	}
 
-	var currentFunc *ast.FuncDecl
-
-	// inspector.Nodes is more efficient than ast.Inspect (~2.5x faster).
-	// The callback is invoked twice for each node: once when entering (push=true)
-	// and once when exiting (push=false). This allows us to track context:
-	// - When entering a FuncDecl, we save it to currentFunc
-	// - When processing AssignStmt, we check it against currentFunc's body
-	// - When exiting a FuncDecl, we reset currentFunc to nil
-	inspector.Nodes(nodeFilter, func(n ast.Node, push bool) bool {
-		// Skip test files by default (unless -include-tests is specified)
-		if !config.IncludeTests {
-			pos := pass.Fset.Position(n.Pos())
-			if strings.HasSuffix(pos.Filename, "_test.go") {
-				return false // Skip this node and its children
-			}
+	var unhandled []unhandledCall
+
+	inspector.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
 		}
 
-		if push {
-			// Entering a node: traveling down the AST tree
-			switch node := n.(type) {
-			case *ast.FuncDecl:
-				// Track the current function scope for context
-				currentFunc = node
-			case *ast.AssignStmt:
-				// Only process assignments inside functions (skip package-level assignments)
-				if currentFunc == nil || currentFunc.Body == nil {
-					return true
-				}
-				checkAssignment(pass, node, currentFunc)
+		// Skip test files, generated files and excluded paths
+		if isSkipped(n) {
+			return false // Skip this node and its children
+		}
+
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			unhandled = append(unhandled, checkAssignment(pass, cfg, inv, node.Lhs, node.Rhs, stack)...)
+			checkPaginatorIteration(pass, inv, node.Lhs, node.Rhs, stack)
+		case *ast.ValueSpec:
+			lhs := make([]ast.Expr, len(node.Names))
+			for i, name := range node.Names {
+				lhs[i] = name
 			}
-		} else {
-			// Exiting a node: traveling back up the AST tree
-			// Clear currentFunc when we exit a function declaration
-			if _, ok := n.(*ast.FuncDecl); ok {
-				currentFunc = nil
+			unhandled = append(unhandled, checkAssignment(pass, cfg, inv, lhs, node.Values, stack)...)
+			checkPaginatorIteration(pass, inv, lhs, node.Values, stack)
+		case *ast.CallExpr:
+			if call, ok := checkCall(pass, cfg, inv, node, stack); ok {
+				unhandled = append(unhandled, call)
 			}
+			checkPagesCallback(pass, inv, node)
 		}
 		return true
 	})
 
-	return nil, nil
+	// Results returned to callers move the pagination obligation to them,
+	// so only the remaining calls are reported here
+	remaining := transferPaginationObligations(pass, unhandled)
+	for _, call := range unhandled {
+		if !slices.ContainsFunc(remaining, func(r unhandledCall) bool { return r.callExpr == call.callExpr }) {
+			inv.calls[call.callExpr].Status = StatusReturned
+		}
+	}
+	for _, call := range remaining {
+		inv.check(inv.calls[call.callExpr], func() { reportUnhandledCall(pass, call) })
+	}
+
+	result.CallSites = inv.callSites()
+	return result, nil
+}
+
+// isTestFile reports whether the node is located in a test file (*_test.go).
+func isTestFile(pass *analysis.Pass, n ast.Node) bool {
+	return strings.HasSuffix(pass.Fset.Position(n.Pos()).Filename, "_test.go")
 }
 
 // extractResultType extracts the result type from a call expression.
@@ -246,80 +497,379 @@ func extractVariableName(lhs ast.Expr) string {
 	return ident.Name
 }
 
-// checkAssignment checks a single assignment statement for missing pagination handling.
-// It examines each call expression on the right-hand side and reports diagnostics
-// for AWS SDK List API calls that lack proper pagination handling.
-func checkAssignment(pass *analysis.Pass, assignStmt *ast.AssignStmt, funcDecl *ast.FuncDecl) {
+// enclosingFunc returns the innermost function in stack, either a *ast.FuncDecl or
+// a *ast.FuncLit, or nil if the node at the top of the stack is at package level
+// (e.g., a call in a package-level variable declaration).
+func enclosingFunc(stack []ast.Node) ast.Node {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch fn := stack[i].(type) {
+		case *ast.FuncDecl, *ast.FuncLit:
+			return fn
+		}
+	}
+	return nil
+}
+
+// declaringFunc returns the innermost function in stack that declares the variable
+// identified by ident, or nil if the variable is declared at package level.
+// A closure may assign a variable captured from an outer function (e.g., a callback
+// passed to a retry helper), in which case the outer function is returned, since that
+// is where the result is consumed and pagination is expected to be handled.
+func declaringFunc(pass *analysis.Pass, ident *ast.Ident, stack []ast.Node) ast.Node {
+	obj := pass.TypesInfo.ObjectOf(ident)
+	if obj == nil {
+		return enclosingFunc(stack)
+	}
+
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch fn := stack[i].(type) {
+		case *ast.FuncDecl, *ast.FuncLit:
+			if fn.Pos() <= obj.Pos() && obj.Pos() < fn.End() {
+				return fn
+			}
+		}
+	}
+	return nil
+}
+
+// funcTypeAndBody returns the signature and body of a *ast.FuncDecl or *ast.FuncLit.
+func funcTypeAndBody(fn ast.Node) (*ast.FuncType, *ast.BlockStmt) {
+	switch fn := fn.(type) {
+	case *ast.FuncDecl:
+		return fn.Type, fn.Body
+	case *ast.FuncLit:
+		return fn.Type, fn.Body
+	}
+	return nil, nil
+}
+
+// unhandledCall is an AWS SDK List API call whose result lacks pagination handling
+// in the function where it is consumed.
+type unhandledCall struct {
+	callExpr    *ast.CallExpr
+	varName     string
+	tokenFields []string
+	apiInfo     apiCallInfo
+
+	// funcDecl is the function declaration returning the unhandled output to its callers
+	// through the results at the indices in results, if any.
+	funcDecl *ast.FuncDecl
+	results  []int
+
+	// fixes are the suggested fixes offered with the diagnostic.
+	fixes []analysis.SuggestedFix
+}
+
+// findHandlingInScope finds pagination handling of the value assigned to obj by callExpr
+// in the body of fn, which is a *ast.FuncDecl or *ast.FuncLit.
+// A nil fn denotes a package-level variable, whose pagination may be handled in any
+// file of the package.
+func findHandlingInScope(pass *analysis.Pass, cfg *Config, fn ast.Node, obj types.Object, callExpr *ast.CallExpr, tokenFields []string) paginationHandling {
+	if fn != nil {
+		_, body := funcTypeAndBody(fn)
+		if body == nil {
+			return paginationHandling{}
+		}
+		return findPaginationHandling(pass, cfg, body, obj, callExpr, callExpr.Pos(), tokenFields)
+	}
+
+	for _, file := range pass.Files {
+		if handling := findPaginationHandling(pass, cfg, file, obj, callExpr, token.NoPos, tokenFields); handling.kind != handlingNone {
+			return handling
+		}
+	}
+	return paginationHandling{}
+}
+
+// extractPaginationInfo extracts the pagination token fields and API call information
+// of a call expression.
+// Returns false if the call does not return an AWS SDK type with pagination token fields.
+func extractPaginationInfo(pass *analysis.Pass, cfg *Config, callExpr *ast.CallExpr) ([]string, apiCallInfo, bool) {
+	// Extract result type from the call expression
+	resultType := extractResultType(pass, callExpr)
+	if resultType == nil {
+		return nil, apiCallInfo{}, false
+	}
+
+	// Pages returned by paginators are already paginated
+	if isPaginatorMethod(pass, callExpr) {
+		return nil, apiCallInfo{}, false
+	}
+
+	// Extract API call information to get service name
+	apiInfo := extractAPICallInfo(callExpr, resultType)
+
+	// Check if the type is from AWS SDK v2 or v1
+	// This prevents false positives from non-AWS code
+	if !isAWSSDKType(resultType) {
+		return nil, apiCallInfo{}, false
+	}
+
+	// Get all pagination token fields for this operation
+	// Pass service name to enable service-specific field detection
+	// For multi-field pagination (e.g., Route53), we check if any field is accessed
+	allTokenFields := paginationTokenFieldsOf(cfg, resultType, apiInfo.serviceName)
+	if len(allTokenFields) == 0 {
+		return nil, apiCallInfo{}, false
+	}
+
+	// Derive the input fields the tokens are fed back into
+	apiInfo.inputName = inputName(inputArg(pass, callExpr))
+	apiInfo.fieldMappings = extractFieldMappings(pass, callExpr, resultType, allTokenFields)
+
+	return allTokenFields, apiInfo, true
+}
+
+// checkAssignment checks a single assignment statement or variable declaration for
+// missing pagination handling.
+// It examines each call expression on the right-hand side, records its call site in inv,
+// and returns the AWS SDK List API calls that lack proper pagination handling.
+func checkAssignment(pass *analysis.Pass, cfg *Config, inv *inventory, lhs, rhs []ast.Expr, stack []ast.Node) []unhandledCall {
+	var unhandled []unhandledCall
+
 	// Check each right-hand side expression
-	for i, rightHandSide := range assignStmt.Rhs {
+	for i, rightHandSide := range rhs {
 		callExpr, ok := rightHandSide.(*ast.CallExpr)
 		if !ok {
 			continue
 		}
 
 		// Get the corresponding left-hand side
-		if i >= len(assignStmt.Lhs) {
-			continue
-		}
-
-		// Extract result type from the call expression
-		resultType := extractResultType(pass, callExpr)
-		if resultType == nil {
+		if i >= len(lhs) {
 			continue
 		}
 
-		// Extract API call information to get service name
-		apiInfo := extractAPICallInfo(callExpr, resultType)
+		// With -explain, the decision about the call is traced along the way
+		trace := explainCall(pass, cfg, callExpr)
 
-		// Check if the result type has a pagination token field
-		// Pass service name to enable service-specific field detection
-		tokenField := hasPaginationTokenField(resultType, apiInfo.serviceName)
-		if tokenField == "" {
+		tokenFields, apiInfo, ok := extractPaginationInfo(pass, cfg, callExpr)
+		if !ok {
+			trace.notChecked()
 			continue
 		}
-
-		// Check if the type is from AWS SDK v2
-		// This prevents false positives from non-AWS code
-		if !isAWSSDKType(resultType) {
+		if operationExcluded(cfg, apiInfo) {
+			inv.record(pass, callExpr, apiInfo, tokenFields, "", StatusExcluded)
+			trace.decide("not reported: the operation is excluded")
 			continue
 		}
 
 		// Extract the variable name being assigned to
-		varName := extractVariableName(assignStmt.Lhs[i])
-		if varName == "" {
+		varName := extractVariableName(lhs[i])
+		ident, _ := lhs[i].(*ast.Ident)
+		if ident != nil && varName == "" {
+			inv.record(pass, callExpr, apiInfo, tokenFields, "", StatusDiscarded)
+			trace.decide("not reported: the result is discarded")
 			continue
 		}
 
-		// Get all pagination token fields for this service
-		// For multi-field pagination (e.g., Route53), we check if any field is accessed
-		allTokenFields := getAllPaginationTokenFields(resultType, apiInfo.serviceName)
-		if len(allTokenFields) == 0 {
+		// Results stored in a struct field, map or slice element or through a pointer
+		// (e.g., s.out, _ = client.ListTasks(...)) leave the function like results consumed
+		// in place, so pagination cannot be handled (see checkCall)
+		if ident == nil {
+			inv.record(pass, callExpr, apiInfo, tokenFields, "", StatusUnhandled)
+			trace.decide("reported: the result is stored in a field or element, so pagination cannot be handled (unless suppressed by a directive)")
+			unhandled = append(unhandled, unhandledCall{
+				callExpr:    callExpr,
+				tokenFields: tokenFields,
+				apiInfo:     apiInfo,
+			})
 			continue
 		}
 
-		// Check if pagination handling exists in the same function
-		if hasPaginationHandling(funcDecl.Body, varName, allTokenFields) {
+		// Check if pagination handling exists in the function declaring the variable
+		obj := pass.TypesInfo.ObjectOf(ident)
+		if obj == nil {
+			inv.record(pass, callExpr, apiInfo, tokenFields, varName, StatusUnchecked)
+			trace.decide("not checked: the variable %s is not resolved", varName)
+			continue
+		}
+		fn := declaringFunc(pass, ident, stack)
+		if fn != nil {
+			trace.add("variable: %s, declared in %s", varName, funcName(fn))
+		} else {
+			trace.add("variable: %s, declared at package level", varName)
+		}
+		handling := findHandlingInScope(pass, cfg, fn, obj, callExpr, tokenFields)
+		trace.evidence(handling)
+		if handling.kind != handlingNone {
+			site := inv.record(pass, callExpr, apiInfo, tokenFields, varName, handling.kind.status())
+
+			// Reading the token is not enough in strict mode: the loop around it is validated too
+			if cfg.StrictLoops && handling.kind == handlingTokenAccess && fn != nil {
+				trace.decide("loop validated: the manual loop is checked by -strict-loops")
+				inv.check(site, func() { checkManualLoop(pass, callExpr, fn, obj, tokenFields, apiInfo) })
+				continue
+			}
+			trace.decide("not reported: pagination is handled")
 			continue
 		}
 
-		// Report the issue with detailed, actionable message
-		pass.Report(analysis.Diagnostic{
-			Pos:     callExpr.Pos(),
-			Message: buildErrorMessage(allTokenFields, varName, apiInfo),
-		})
+		// Calls limited to a single page on purpose are handled according to the policy,
+		// unless the operation is always checked
+		limitable := cfg.LimitedCalls == limitPolicyInfo || cfg.LimitedCalls == limitPolicyIgnore
+		if limitable && !matchesOperation(cfg.IncludeOperations, apiInfo) {
+			if field := limitedCall(pass, fn, obj, callExpr); field != "" {
+				inv.record(pass, callExpr, apiInfo, tokenFields, varName, StatusLimited)
+				trace.decide("not reported as missing pagination: the call is limited to a single page by %s (-limited-calls=%s)", field, cfg.LimitedCalls.String())
+				if cfg.LimitedCalls == limitPolicyInfo {
+					reportLimitedCall(pass, callExpr, field, apiInfo)
+				}
+				continue
+			}
+		}
+
+		call := unhandledCall{
+			callExpr:    callExpr,
+			varName:     varName,
+			tokenFields: tokenFields,
+			apiInfo:     apiInfo,
+		}
+		site := inv.record(pass, callExpr, apiInfo, tokenFields, varName, StatusUnhandled)
+
+		// Results returned from function literals are checked where the literal is called
+		if results := returnedResults(pass, fn, obj); len(results) > 0 {
+			funcDecl, ok := fn.(*ast.FuncDecl)
+			if !ok {
+				site.Status = StatusReturned
+				trace.decide("not reported: the result is returned from a function literal, checked where it is called")
+				continue
+			}
+			call.funcDecl = funcDecl
+			call.results = results
+			trace.decide("not reported here: the result is returned, so the callers of %s are checked instead", funcDeclName(funcDecl))
+		} else {
+			trace.decide("reported: no pagination handling found (unless suppressed by a directive)")
+		}
+
+		// Offer to rewrite the call into a paginator loop when its shape allows it
+		if assign, ok := stack[len(stack)-1].(*ast.AssignStmt); ok {
+			if fix := paginatorLoopFix(pass, assign, stack, fn); fix != nil {
+				call.fixes = append(call.fixes, *fix)
+			}
+		}
+
+		unhandled = append(unhandled, call)
 	}
+
+	return unhandled
+}
+
+// checkCall checks a call expression whose result is not assigned to a variable.
+// The rule depends on how the parent node consumes the result:
+//   - Assignment or declaration: checked by checkAssignment instead, which reports results
+//     stored in a field or element like results consumed in place
+//   - Expression, defer or go statement: the result is discarded, nothing to paginate
+//   - Return statement: the obligation moves to the callers of the function
+//   - Argument: handled if the callee handles pagination of that parameter
+//   - Anything else (composite literal field, selector, ...): the result is consumed
+//     in place, so pagination cannot be handled
+//
+// The call site is recorded in inv.
+// Returns false if the call does not lack pagination handling.
+func checkCall(pass *analysis.Pass, cfg *Config, inv *inventory, callExpr *ast.CallExpr, stack []ast.Node) (unhandledCall, bool) {
+	parent := stack[len(stack)-2]
+	switch parent.(type) {
+	case *ast.AssignStmt, *ast.ValueSpec:
+		return unhandledCall{}, false
+	}
+
+	// With -explain, the decision about the call is traced along the way
+	trace := explainCall(pass, cfg, callExpr)
+
+	tokenFields, apiInfo, ok := extractPaginationInfo(pass, cfg, callExpr)
+	if !ok {
+		trace.notChecked()
+		return unhandledCall{}, false
+	}
+	if operationExcluded(cfg, apiInfo) {
+		inv.record(pass, callExpr, apiInfo, tokenFields, "", StatusExcluded)
+		trace.decide("not reported: the operation is excluded")
+		return unhandledCall{}, false
+	}
+
+	switch parent.(type) {
+	case *ast.ExprStmt, *ast.DeferStmt, *ast.GoStmt:
+		inv.record(pass, callExpr, apiInfo, tokenFields, "", StatusDiscarded)
+		trace.decide("not reported: the result is discarded")
+		return unhandledCall{}, false
+	}
+
+	call := unhandledCall{
+		callExpr:    callExpr,
+		tokenFields: tokenFields,
+		apiInfo:     apiInfo,
+	}
+	site := inv.record(pass, callExpr, apiInfo, tokenFields, "", StatusUnhandled)
+
+	switch parent := parent.(type) {
+	case *ast.ReturnStmt:
+		// Results returned from function literals are checked where the literal is called
+		funcDecl, ok := enclosingFunc(stack).(*ast.FuncDecl)
+		if !ok {
+			site.Status = StatusReturned
+			trace.decide("not reported: the result is returned from a function literal, checked where it is called")
+			return unhandledCall{}, false
+		}
+		call.funcDecl = funcDecl
+		for i, result := range parent.Results {
+			if result == callExpr {
+				call.results = []int{i}
+			}
+		}
+		trace.decide("not reported here: the result is returned, so the callers of %s are checked instead", funcDeclName(funcDecl))
+		return call, true
+	case *ast.CallExpr:
+		// A multi-value call as the only argument spreads its results over the parameters,
+		// so its first result is passed as the first argument either way
+		fact := paginationHandlerFactOf(pass, parent)
+		for i, arg := range parent.Args {
+			if arg == callExpr && fact != nil && fact.handles(i) {
+				site.Status = StatusHelper
+				trace.decide("not reported: the result is passed to %s, which handles its pagination", nodeString(parent.Fun))
+				return unhandledCall{}, false
+			}
+		}
+		trace.decide("reported: the result is passed to %s, which does not handle its pagination (unless suppressed by a directive)", nodeString(parent.Fun))
+		return call, true
+	}
+
+	trace.decide("reported: the result is consumed in place, so pagination cannot be handled (unless suppressed by a directive)")
+	return call, true
+}
+
+// reportUnhandledCall reports a call lacking pagination handling with a detailed, actionable message.
+// If the called function returned the result of another List API call without paginating,
+// as recorded by a paginationObligationFact, the diagnostic points back at that call.
+func reportUnhandledCall(pass *analysis.Pass, call unhandledCall) {
+	diagnostic := analysis.Diagnostic{
+		Pos:            call.callExpr.Pos(),
+		Category:       ruleMissingPagination,
+		SuggestedFixes: call.fixes,
+	}
+
+	if fact := paginationObligationFactOf(pass, call.callExpr); fact != nil {
+		// Suggest the paginator of the originating operation rather than the wrapper
+		call.apiInfo.methodName = fact.Method
+		diagnostic.Related = []analysis.RelatedInformation{{
+			Pos:     fact.originPos(pass, call.callExpr),
+			Message: fact.Method + " result is returned here without pagination handling",
+		}}
+	}
+
+	diagnostic.Message = buildErrorMessage(call.tokenFields, call.varName, call.apiInfo)
+	report(pass, diagnostic)
 }
 
 // getAllPaginationTokenFields returns all pagination token field names for a given type and service.
 // This is used for services with multi-field pagination (e.g., Route53) where we need to check
 // if any of the fields are accessed, not just the first one found.
 // Returns a slice of field names that exist in the type.
-func getAllPaginationTokenFields(t types.Type, serviceName string) []string {
+func getAllPaginationTokenFields(cfg *Config, t types.Type, serviceName string) []string {
 	var fields []string
 
 	// Check service-specific pagination fields if service is known
 	if serviceName != "" {
-		if serviceFields, ok := apiSpecificPaginationFields[strings.ToLower(serviceName)]; ok {
+		if serviceFields, ok := getServiceTokenFields(cfg, serviceName); ok {
 			for _, field := range serviceFields {
 				// Create a new seen map for each field check to avoid false negatives
 				seen := make(map[types.Type]bool)
@@ -336,7 +886,7 @@ func getAllPaginationTokenFields(t types.Type, serviceName string) []string {
 
 	// Check default pagination token fields
 	seen := make(map[types.Type]bool)
-	defaultField := hasPaginationTokenFieldRecursive(t, seen)
+	defaultField := hasPaginationTokenFieldRecursive(cfg, t, seen)
 	if defaultField != "" {
 		fields = append(fields, defaultField)
 	}
@@ -344,28 +894,6 @@ func getAllPaginationTokenFields(t types.Type, serviceName string) []string {
 	return fields
 }
 
-// hasPaginationTokenField checks if the type has any pagination token field.
-// It checks both service-specific pagination fields and default pagination token fields.
-// Returns the field name if found, empty string otherwise.
-// This function checks both direct fields and embedded struct fields recursively.
-func hasPaginationTokenField(t types.Type, serviceName string) string {
-	seen := make(map[types.Type]bool)
-
-	// First check service-specific pagination fields if service is known
-	if serviceName != "" {
-		if fields, ok := apiSpecificPaginationFields[strings.ToLower(serviceName)]; ok {
-			for _, field := range fields {
-				if hasSpecificField(t, field, seen) {
-					return field
-				}
-			}
-		}
-	}
-
-	// Then check default pagination token fields
-	return hasPaginationTokenFieldRecursive(t, seen)
-}
-
 // hasSpecificField checks if a type has a specific field name.
 // This is used for service-specific pagination fields that may have different types.
 // Returns true if the field exists, regardless of its type.
@@ -415,7 +943,7 @@ func hasSpecificField(t types.Type, fieldName string, seen map[types.Type]bool)
 
 // hasPaginationTokenFieldRecursive recursively checks for pagination token fields
 // The seen map prevents infinite recursion on circular struct embeddings
-func hasPaginationTokenFieldRecursive(t types.Type, seen map[types.Type]bool) string {
+func hasPaginationTokenFieldRecursive(cfg *Config, t types.Type, seen map[types.Type]bool) string {
 	// Unwrap pointer types
 	if ptr, ok := t.(*types.Pointer); ok {
 		t = ptr.Elem()
@@ -440,7 +968,7 @@ func hasPaginationTokenFieldRecursive(t types.Type, seen map[types.Type]bool) st
 
 	// Look for any pagination token field (in priority order)
 	// Check direct fields first (prioritizing Next* fields over input fields)
-	for _, tokenField := range getPaginationTokenFields() {
+	for _, tokenField := range getPaginationTokenFields(cfg) {
 		for i := 0; i < st.NumFields(); i++ {
 			field := st.Field(i)
 			if field.Name() == tokenField {
@@ -453,7 +981,7 @@ func hasPaginationTokenFieldRecursive(t types.Type, seen map[types.Type]bool) st
 	for i := 0; i < st.NumFields(); i++ {
 		field := st.Field(i)
 		if field.Embedded() {
-			if tokenField := hasPaginationTokenFieldRecursive(field.Type(), seen); tokenField != "" {
+			if tokenField := hasPaginationTokenFieldRecursive(cfg, field.Type(), seen); tokenField != "" {
 				return tokenField
 			}
 		}
@@ -461,44 +989,112 @@ func hasPaginationTokenFieldRecursive(t types.Type, seen map[types.Type]bool) st
 	return ""
 }
 
-// hasPaginationHandling checks if pagination handling exists in the function body.
-// It detects two patterns of pagination implementation:
+// handlingKind identifies a pattern of pagination handling.
+type handlingKind int
+
+const (
+	// handlingNone means no pagination handling was found.
+	handlingNone handlingKind = iota
+
+	// handlingTokenAccess means a pagination token field of the result is read (manual loop).
+	handlingTokenAccess
+
+	// handlingPaginator means an AWS SDK paginator is used.
+	handlingPaginator
+
+	// handlingHelper means the result is passed to a function handling its pagination.
+	handlingHelper
+)
+
+// status returns the status of call sites whose result is handled this way.
+func (k handlingKind) status() CallStatus {
+	switch k {
+	case handlingTokenAccess:
+		return StatusManualLoop
+	case handlingPaginator:
+		return StatusPaginator
+	case handlingHelper:
+		return StatusHelper
+	}
+	return StatusUnhandled
+}
+
+// paginationHandling describes the pagination handling found for a result.
+type paginationHandling struct {
+	kind handlingKind
+
+	// node is the AST node taken as evidence of the handling.
+	node ast.Node
+}
+
+// hasPaginationHandling checks if pagination handling of the value assigned to obj at
+// assignPos exists in the function body.
+// See findPaginationHandling for the detected patterns.
+func hasPaginationHandling(pass *analysis.Pass, cfg *Config, body ast.Node, obj types.Object, callExpr *ast.CallExpr, assignPos token.Pos, tokenFields []string) bool {
+	return findPaginationHandling(pass, cfg, body, obj, callExpr, assignPos, tokenFields).kind != handlingNone
+}
+
+// findPaginationHandling finds pagination handling of the value assigned to obj at
+// assignPos in the function body.
+// callExpr is the call producing the value, or nil if the value is a parameter.
+// Only references to that value count, as tracked by resultValue: other variables with
+// the same name, or the variable after it has been reassigned, are not considered.
+// It detects three patterns of pagination implementation:
 //  1. Manual loop: Direct access to pagination token field (e.g., result.NextToken, result.NextMarker)
 //     For multi-field pagination (e.g., Route53), checks if ANY of the fields are accessed
-//  2. Paginator: Usage of AWS SDK paginator (NewXXXPaginator, HasMorePages, NextPage methods)
+//     With -strict-token-use, only meaningful reads count, see meaningfulTokenRead
+//  2. Paginator: Construction of the AWS SDK paginator of the same operation, from the same
+//     input as callExpr (e.g., ecs.NewListTasksPaginator(client, input) for
+//     client.ListTasks(ctx, input)). See paginatedOperation for the matching rules.
+//  3. Helper: Passing the result to a function that handles pagination of that parameter,
+//     as recorded by a paginationHandlerFact (possibly exported by another package)
 //
-// Returns true if either pattern is found, indicating that pagination is properly handled.
-func hasPaginationHandling(body *ast.BlockStmt, varName string, tokenFields []string) bool {
+// Paginators and helpers take precedence over token accesses, since a token access alone
+// does not guarantee a complete manual loop.
+// Returns a paginationHandling of kind handlingNone if no pattern is found.
+func findPaginationHandling(pass *analysis.Pass, cfg *Config, body ast.Node, obj types.Object, callExpr *ast.CallExpr, assignPos token.Pos, tokenFields []string) paginationHandling {
+	value := newResultValue(pass, body, obj, assignPos)
+	op := newPaginatedOperation(pass, callExpr, obj.Type())
+
 	// Pattern 1: Manual loop with pagination token access
-	hasTokenAccess := false
+	var tokenAccess ast.Node
 
 	// Pattern 2: Paginator usage
-	hasPaginatorUsage := false
+	var paginatorUsage ast.Node
 
+	// Pattern 3: Helper function that handles pagination
+	var helperUsage ast.Node
+
+	// stack holds the ancestors of the visited node, which tell how a token is used
+	var stack []ast.Node
 	ast.Inspect(body, func(node ast.Node) bool {
+		if node == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		defer func() { stack = append(stack, node) }()
+
 		// Check for pagination token field access (e.g., result.NextToken, result.NextMarker)
-		if sel, ok := node.(*ast.SelectorExpr); ok {
+		if sel, ok := node.(*ast.SelectorExpr); ok && tokenAccess == nil {
 			// Check if accessing any of the pagination token fields
-			for _, tokenField := range tokenFields {
-				if sel.Sel.Name == tokenField {
-					if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == varName {
-						hasTokenAccess = true
-						break
-					}
-				}
+			if slices.Contains(tokenFields, sel.Sel.Name) && value.refersTo(sel.X) &&
+				(!cfg.StrictTokenUse || meaningfulTokenRead(pass, body, sel, stack)) {
+				tokenAccess = sel
 			}
 		}
 
-		// Check for Paginator usage
+		// Check for a paginator of the same operation
 		if callExpr, ok := node.(*ast.CallExpr); ok {
-			if sel, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
-				// NewXXXPaginator pattern
-				if len(sel.Sel.Name) > 9 && sel.Sel.Name[len(sel.Sel.Name)-9:] == "Paginator" {
-					hasPaginatorUsage = true
-				}
-				// HasMorePages, NextPage methods
-				if sel.Sel.Name == "HasMorePages" || sel.Sel.Name == "NextPage" {
-					hasPaginatorUsage = true
+			if paginatorUsage == nil && op.matchesPaginator(pass, callExpr) {
+				paginatorUsage = callExpr
+			}
+
+			// Check if the result is passed to a helper that handles its pagination
+			if fact := paginationHandlerFactOf(pass, callExpr); fact != nil && helperUsage == nil {
+				for i, arg := range callExpr.Args {
+					if value.refersTo(arg) && fact.handles(i) {
+						helperUsage = callExpr
+					}
 				}
 			}
 		}
@@ -506,10 +1102,18 @@ func hasPaginationHandling(body *ast.BlockStmt, varName string, tokenFields []st
 		return true
 	})
 
-	return hasTokenAccess || hasPaginatorUsage
+	switch {
+	case helperUsage != nil:
+		return paginationHandling{kind: handlingHelper, node: helperUsage}
+	case paginatorUsage != nil:
+		return paginationHandling{kind: handlingPaginator, node: paginatorUsage}
+	case tokenAccess != nil:
+		return paginationHandling{kind: handlingTokenAccess, node: tokenAccess}
+	}
+	return paginationHandling{}
 }
 
-// isAWSSDKType checks if the type originates from AWS SDK v2
+// isAWSSDKType checks if the type originates from AWS SDK v2 or v1
 // This function recursively checks the type and all embedded types to determine
 // if any part of the type hierarchy comes from AWS SDK.
 // This handles:
@@ -522,7 +1126,7 @@ func isAWSSDKType(t types.Type) bool {
 }
 
 // isAWSSDKTypeRecursive recursively checks if a type or any of its embedded types
-// originates from AWS SDK v2
+// originates from AWS SDK v2 or v1
 func isAWSSDKTypeRecursive(t types.Type, seen map[types.Type]bool) bool {
 	// Unwrap pointer types
 	if ptr, ok := t.(*types.Pointer); ok {
@@ -559,35 +1163,45 @@ func isAWSSDKTypeRecursive(t types.Type, seen map[types.Type]bool) bool {
 	return false
 }
 
-// isAWSSDKPackage checks if a package path is from AWS SDK v2.
+// awsSDKServicePaths are the path segments identifying AWS SDK service packages,
+// for AWS SDK for Go v2 and v1 respectively.
+var awsSDKServicePaths = []string{"aws-sdk-go-v2/service/", "aws-sdk-go/service/"}
+
+// isAWSSDKPackage checks if a package path is from AWS SDK v2 or v1.
 // Uses Contains instead of HasPrefix to handle various SDK distribution scenarios:
 // - Official SDK: github.com/aws/aws-sdk-go-v2/service/...
 // - Forks: github.com/mycompany/aws-sdk-go-v2/service/...
 // - Proxies: proxy.company.com/github.com/aws/aws-sdk-go-v2/service/...
 // - Vendored: .../vendor/github.com/aws/aws-sdk-go-v2/service/...
 // The key identifier "aws-sdk-go-v2/service/" is consistent across all these variants
-// and uniquely identifies AWS SDK v2 service packages.
+// and uniquely identifies AWS SDK v2 service packages ("aws-sdk-go/service/" for v1).
 func isAWSSDKPackage(pkgPath string) bool {
-	// Check for AWS SDK v2 service packages
+	// Check for AWS SDK service packages
 	// We use Contains instead of HasPrefix to handle forks and proxies
-	// The key identifier is "aws-sdk-go-v2/service/" which is consistent
-	// across forks and proxies
-	return strings.Contains(pkgPath, "aws-sdk-go-v2/service/")
+	for _, servicePath := range awsSDKServicePaths {
+		if strings.Contains(pkgPath, servicePath) {
+			return true
+		}
+	}
+	return false
 }
 
 // extractServiceNameFromPackage extracts the service name from a package path
 // Example: "github.com/aws/aws-sdk-go-v2/service/s3" -> "s3"
 func extractServiceNameFromPackage(pkgPath string) string {
-	idx := strings.Index(pkgPath, "aws-sdk-go-v2/service/")
-	if idx < 0 {
-		return ""
-	}
-	servicePath := pkgPath[idx+len("aws-sdk-go-v2/service/"):]
-	// Handle sub-packages (e.g., "s3/types" -> "s3")
-	if slashIdx := strings.Index(servicePath, "/"); slashIdx >= 0 {
-		return servicePath[:slashIdx]
+	for _, prefix := range awsSDKServicePaths {
+		idx := strings.Index(pkgPath, prefix)
+		if idx < 0 {
+			continue
+		}
+		servicePath := pkgPath[idx+len(prefix):]
+		// Handle sub-packages (e.g., "s3/types" -> "s3", "s3/s3iface" -> "s3")
+		if slashIdx := strings.Index(servicePath, "/"); slashIdx >= 0 {
+			return servicePath[:slashIdx]
+		}
+		return servicePath
 	}
-	return servicePath
+	return ""
 }
 
 // apiCallInfo contains information about an AWS SDK API call.
@@ -604,6 +1218,19 @@ type apiCallInfo struct {
 	// typeName is the full output type name (e.g., "ListBucketsOutput", "ListTasksOutput").
 	// Empty if the result type is not a named type.
 	typeName string
+
+	// inputName is the name of the input variable passed to the call (e.g., "input").
+	// Defaults to "input" if the input is not a variable.
+	inputName string
+
+	// fieldMappings maps the pagination token fields of the output to the input fields
+	// they are fed back into (e.g., NextMarker -> Marker).
+	// Empty if the input type of the operation is unknown.
+	fieldMappings []tokenFieldMapping
+
+	// sdkV1 is true if the result type comes from AWS SDK for Go v1, which paginates
+	// with <Operation>Pages methods instead of paginators.
+	sdkV1 bool
 }
 
 // extractAPICallInfo extracts API call information from a call expression
@@ -621,15 +1248,26 @@ func extractAPICallInfo(callExpr *ast.CallExpr, resultType types.Type) apiCallIn
 	}
 	if named, ok := resultType.(*types.Named); ok {
 		info.typeName = named.Obj().Name()
-		pkg := named.Obj().Pkg()
-		if pkg != nil {
-			info.serviceName = extractServiceNameFromPackage(pkg.Path())
-		}
 	}
+	info.serviceName = serviceNameOfType(resultType)
+	info.sdkV1 = isAWSSDKv1Type(resultType)
 
 	return info
 }
 
+// serviceNameOfType returns the AWS service name of the package declaring t,
+// or empty string if t is not a named type from an AWS SDK package.
+func serviceNameOfType(t types.Type) string {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return ""
+	}
+	return extractServiceNameFromPackage(named.Obj().Pkg().Path())
+}
+
 // buildErrorMessage constructs a concise, actionable error message.
 // The message explains the problem, its impact, and the solution.
 // For multi-field pagination (e.g., Route53), tokenFields contains multiple field names.
@@ -664,7 +1302,9 @@ func buildErrorMessage(tokenFields []string, varName string, info apiCallInfo) s
 	msg.WriteString("\nWhen there are many results, only the first page is returned. Use ")
 
 	// Suggest solution (context-aware if possible)
-	if info.serviceName != "" && info.methodName != "" {
+	if info.serviceName != "" && info.methodName != "" && info.sdkV1 {
+		msg.WriteString(pagesMethodName(info.methodName))
+	} else if info.serviceName != "" && info.methodName != "" {
 		msg.WriteString("New" + info.methodName + "Paginator")
 	} else {
 		msg.WriteString("a paginator")
@@ -678,12 +1318,31 @@ func buildErrorMessage(tokenFields []string, varName string, info apiCallInfo) s
 	// Suggest field access pattern
 	if len(tokenFields) > 0 {
 		if len(tokenFields) == 1 {
-			msg.WriteString(tokenFields[0] + ".")
+			msg.WriteString(tokenFields[0])
 		} else {
 			// For multi-field, suggest checking any of the fields
-			msg.WriteString(tokenFields[0] + " (or other pagination fields).")
+			msg.WriteString(tokenFields[0] + " (or other pagination fields)")
+		}
+	}
+
+	// Show the exact assignments feeding the tokens back into the input
+	if len(info.fieldMappings) > 0 {
+		inputName, resultName := info.inputName, varName
+		if inputName == "" {
+			inputName = "input"
+		}
+		if resultName == "" {
+			resultName = "result"
+		}
+		msg.WriteString(", feeding it back with ")
+		for i, mapping := range info.fieldMappings {
+			if i > 0 {
+				msg.WriteString(", ")
+			}
+			msg.WriteString(mapping.assignment(inputName, resultName))
 		}
 	}
+	msg.WriteString(".")
 
 	return msg.String()
 }
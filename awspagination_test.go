@@ -1,28 +1,318 @@
 package awspagination_test
 
 import (
+	"go/token"
+	"os"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/koh-sh/awspagination"
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/analysistest"
 )
 
 func Test(t *testing.T) {
 	testdata := analysistest.TestData()
-	analysistest.Run(t, testdata, awspagination.Analyzer, "test")
+	results := analysistest.Run(t, testdata, awspagination.Analyzer, "test")
+
+	// Every diagnostic is categorized by one of the documented rules
+	for _, result := range results {
+		for _, diagnostic := range result.Diagnostics {
+			if !slices.ContainsFunc(awspagination.Rules, func(rule awspagination.Rule) bool { return rule.ID == diagnostic.Category }) {
+				t.Errorf("%s: diagnostic of unknown rule %q", result.Pass.Fset.Position(diagnostic.Pos), diagnostic.Category)
+			}
+		}
+	}
 }
 
 // TestIncludeTestFiles verifies that test files are analyzed when -include-tests=true
 func TestIncludeTestFiles(t *testing.T) {
-	// Enable test file analysis
-	_ = awspagination.Analyzer.Flags.Set("include-tests", "true")
-	defer func() {
-		// Restore to default
-		_ = awspagination.Analyzer.Flags.Set("include-tests", "false")
-	}()
+	// Enable test file analysis on an analyzer of its own
+	analyzer := awspagination.NewAnalyzer(awspagination.Config{})
+	if err := analyzer.Flags.Set("include-tests", "true"); err != nil {
+		t.Fatal(err)
+	}
 
 	testdata := analysistest.TestData()
 	// Run analysis on testskip package - skip_test.go should be analyzed
 	// and the want comments should be validated
-	analysistest.Run(t, testdata, awspagination.Analyzer, "testskip")
+	analysistest.Run(t, testdata, analyzer, "testskip")
+}
+
+// TestObligationRelatedInformation verifies that diagnostics on callers of functions
+// returning unpaginated results point back at the originating SDK call
+func TestObligationRelatedInformation(t *testing.T) {
+	testdata := analysistest.TestData()
+	results := analysistest.Run(t, testdata, awspagination.Analyzer, "test")
+
+	// Calls expected to carry related information, keyed by the called wrapper
+	want := map[string]string{
+		"listObjects(client)":             "client.ListObjectsV2(",
+		"listObjectsNamed(client)":        "client.ListObjectsV2(",
+		"listObjectsWrapped(client)":      "client.ListObjectsV2(",
+		"pagehelper.FetchBuckets(client)": "client.ListBuckets(",
+	}
+
+	found := make(map[string]bool)
+	for _, result := range results {
+		for _, diagnostic := range result.Diagnostics {
+			if len(diagnostic.Related) == 0 {
+				continue
+			}
+			call := sourceAt(t, result.Pass.Fset.Position(diagnostic.Pos))
+			origin := sourceAt(t, result.Pass.Fset.Position(diagnostic.Related[0].Pos))
+			for wrapper, sdkCall := range want {
+				if strings.HasPrefix(call, wrapper) {
+					found[wrapper] = true
+					if !strings.HasPrefix(origin, sdkCall) {
+						t.Errorf("related information of %s points at %q, want %q", wrapper, origin, sdkCall)
+					}
+				}
+			}
+		}
+	}
+
+	for wrapper := range want {
+		if !found[wrapper] {
+			t.Errorf("no diagnostic with related information reported for %s", wrapper)
+		}
+	}
+}
+
+// sourceAt returns the source text of the line at position, starting at its column
+func sourceAt(t *testing.T, position token.Position) string {
+	t.Helper()
+	content, err := os.ReadFile(position.Filename)
+	if err != nil {
+		t.Fatalf("reading %s: %v", position.Filename, err)
+	}
+	lines := strings.Split(string(content), "\n")
+	return lines[position.Line-1][position.Column-1:]
+}
+
+// TestStrictLoops verifies that manual pagination loops are validated when -strict-loops=true
+func TestStrictLoops(t *testing.T) {
+	analyzer := awspagination.NewAnalyzer(awspagination.Config{StrictLoops: true})
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "test/strictloops")
+}
+
+// TestStrictTokenUse verifies that reads of pagination tokens that are only logged or
+// discarded do not count as handling when -strict-token-use=true
+func TestStrictTokenUse(t *testing.T) {
+	analyzer := awspagination.NewAnalyzer(awspagination.Config{StrictTokenUse: true})
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "test/stricttokenuse")
+}
+
+// TestSuggestedFixes verifies the suggested fixes against the golden files
+func TestSuggestedFixes(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, awspagination.Analyzer, "test/fixes")
+}
+
+// TestLimitedCalls verifies the policies for calls limited to a single page by a page-size field
+func TestLimitedCalls(t *testing.T) {
+	tests := []struct {
+		policy  string
+		pattern string
+	}{
+		{policy: "info", pattern: "test/limited"},
+		{policy: "ignore", pattern: "test/limitedignore"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			analyzer := awspagination.NewAnalyzer(awspagination.Config{})
+			if err := analyzer.Flags.Set("limited-calls", tt.policy); err != nil {
+				t.Fatal(err)
+			}
+
+			testdata := analysistest.TestData()
+			analysistest.Run(t, testdata, analyzer, tt.pattern)
+		})
+	}
+}
+
+// TestNewAnalyzerIndependence verifies that analyzers with different configurations do
+// not share state: each one runs in parallel with the others and must report exactly the
+// diagnostics of its own configuration. Run with -race to detect shared mutable state.
+func TestNewAnalyzerIndependence(t *testing.T) {
+	newFromSettings := func(settings map[string]any) *analysis.Analyzer {
+		analyzers, err := awspagination.New(settings)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return analyzers[0]
+	}
+
+	tests := []struct {
+		name     string
+		analyzer *analysis.Analyzer
+		pattern  string
+	}{
+		{name: "default", analyzer: awspagination.Analyzer, pattern: "test"},
+		{name: "strict-loops", analyzer: awspagination.NewAnalyzer(awspagination.Config{StrictLoops: true}), pattern: "test/strictloops"},
+		{name: "strict-token-use", analyzer: awspagination.NewAnalyzer(awspagination.Config{StrictTokenUse: true}), pattern: "test/stricttokenuse"},
+		{name: "include-tests", analyzer: awspagination.NewAnalyzer(awspagination.Config{IncludeTests: true}), pattern: "testskip"},
+		{name: "limited-calls", analyzer: newFromSettings(map[string]any{"limited-calls": "info"}), pattern: "test/limited"},
+		{name: "limited-calls ignore", analyzer: newFromSettings(map[string]any{"limited-calls": "ignore"}), pattern: "test/limitedignore"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			testdata := analysistest.TestData()
+			analysistest.Run(t, testdata, tt.analyzer, tt.pattern)
+		})
+	}
+}
+
+// TestServiceFields verifies that service-specific pagination fields configured with
+// -service-field are checked in addition to the built-in services
+func TestServiceFields(t *testing.T) {
+	analyzer := awspagination.NewAnalyzer(awspagination.Config{})
+	if err := analyzer.Flags.Set("service-field", "dynamodb:LastEvaluatedBackupArn"); err != nil {
+		t.Fatal(err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "test/servicefields")
+}
+
+// TestOperationFilters verifies that excluded operations are not checked and that included
+// operations take precedence over exclusions and the limited-calls policy
+func TestOperationFilters(t *testing.T) {
+	analyzer := awspagination.NewAnalyzer(awspagination.Config{})
+	flags := map[string]string{
+		"exclude-operations": "ecs.ListClusters,iam.List*,ListObjectVersionsOutput",
+		"include-operations": "iam.ListUsers,s3.ListObjectsV2",
+		"limited-calls":      "ignore",
+	}
+	for name, value := range flags {
+		if err := analyzer.Flags.Set(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "test/operationfilter")
+}
+
+// TestExcludePaths verifies that files matching -exclude-paths and generated files are skipped
+func TestExcludePaths(t *testing.T) {
+	analyzer := awspagination.NewAnalyzer(awspagination.Config{})
+	if err := analyzer.Flags.Set("exclude-paths", "internal/mocks/**,**/zz_generated*.go"); err != nil {
+		t.Fatal(err)
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "test/exclude/...")
+}
+
+// TestIncludeGenerated verifies that generated files are analyzed when -include-generated=true
+func TestIncludeGenerated(t *testing.T) {
+	analyzer := awspagination.NewAnalyzer(awspagination.Config{IncludeGenerated: true})
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "test/generated")
+}
+
+// TestFingerprints verifies the fingerprints of the findings in the result of the analyzer
+func TestFingerprints(t *testing.T) {
+	testdata := analysistest.TestData()
+	results := analysistest.Run(t, testdata, awspagination.Analyzer, "test/fingerprint")
+
+	missing := "missing pagination handling for AWS SDK List API call (result has NextToken field)"
+	want := []awspagination.Fingerprint{
+		{Package: "test/fingerprint", Function: "lister.listTasks", Service: "ecs", Operation: "ListTasks", Variable: "tasks", Message: missing},
+		{Package: "test/fingerprint", Function: "listServices", Service: "ecs", Operation: "ListServices", Variable: "services", Message: missing},
+		{Package: "test/fingerprint", Function: "listClusters", Service: "ecs", Operation: "ListClusters", Message: missing},
+		{
+			Package: "test/fingerprint", Function: "firstPage", Service: "ecs", Operation: "ListTasks", Variable: "page",
+			Message: "incomplete paginator iteration: paginator.NextPage is not called in a loop conditioned on paginator.HasMorePages, so only the first page is read",
+		},
+		{Package: "test/fingerprint", Function: "listAllTasks", Message: "unused //awspagination:ignore directive: no diagnostic is suppressed"},
+	}
+
+	var got []awspagination.Fingerprint
+	for _, result := range results {
+		r, ok := result.Result.(*awspagination.Result)
+		if !ok {
+			t.Fatalf("result of type %T, want *awspagination.Result", result.Result)
+		}
+		for _, finding := range r.Findings {
+			got = append(got, finding.Fingerprint)
+		}
+	}
+
+	for _, fingerprint := range want {
+		if !slices.Contains(got, fingerprint) {
+			t.Errorf("missing fingerprint %+v in %+v", fingerprint, got)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("got %d fingerprints, want %d", len(got), len(want))
+	}
+}
+
+// TestCallSites verifies the call sites in the result of the analyzer and their statuses
+func TestCallSites(t *testing.T) {
+	analyzer := awspagination.NewAnalyzer(awspagination.Config{})
+	flags := map[string]string{
+		"exclude-operations": "ecs.ListClusters",
+		"limited-calls":      "ignore",
+	}
+	for name, value := range flags {
+		if err := analyzer.Flags.Set(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testdata := analysistest.TestData()
+	results := analysistest.Run(t, testdata, analyzer, "test/inventory")
+
+	type callSite struct {
+		function, method, variable string
+		status                     awspagination.CallStatus
+		rule, reason               string
+	}
+	want := []callSite{
+		{function: "paginator", method: "NewListTasksPaginator", variable: "tasks", status: awspagination.StatusPaginator},
+		{function: "manualLoop", method: "ListServices", variable: "services", status: awspagination.StatusManualLoop},
+		{function: "helper", method: "ListContainerInstances", variable: "instances", status: awspagination.StatusHelper},
+		{function: "returned", method: "ListObjectsV2", status: awspagination.StatusReturned},
+		{function: "limited", method: "ListTaskDefinitions", variable: "first", status: awspagination.StatusLimited},
+		{function: "excluded", method: "ListClusters", status: awspagination.StatusExcluded},
+		{function: "discarded", method: "ListTaskDefinitionFamilies", status: awspagination.StatusDiscarded},
+		{function: "store.stored", method: "ListTasks", status: awspagination.StatusUnhandled, rule: "missing-pagination"},
+		{function: "suppressed", method: "ListServices", variable: "services", status: awspagination.StatusSuppressed, rule: "missing-pagination", reason: "only the first services are shown"},
+		{function: "unhandled", method: "ListTasks", variable: "tasks", status: awspagination.StatusUnhandled, rule: "missing-pagination"},
+		{function: "firstPage", method: "NewListTasksPaginator", variable: "tasks", status: awspagination.StatusUnhandled, rule: "incomplete-iteration"},
+		{function: "pages", method: "ListUsersPages", status: awspagination.StatusPaginator},
+	}
+
+	var got []callSite
+	for _, result := range results {
+		r, ok := result.Result.(*awspagination.Result)
+		if !ok {
+			t.Fatalf("result of type %T, want *awspagination.Result", result.Result)
+		}
+		for _, site := range r.CallSites {
+			if site.Package != "test/inventory" || site.Service == "" || site.Operation == "" || len(site.TokenFields) == 0 {
+				t.Errorf("incomplete call site %+v", site)
+			}
+			got = append(got, callSite{
+				function: site.Function, method: site.Method, variable: site.Variable,
+				status: site.Status, rule: site.Rule, reason: site.Reason,
+			})
+		}
+	}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("call sites:\n got %+v\nwant %+v", got, want)
+	}
 }
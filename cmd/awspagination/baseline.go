@@ -0,0 +1,116 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+
+	"github.com/koh-sh/awspagination"
+)
+
+// baselineVersion is the version of the baseline file format.
+const baselineVersion = 1
+
+// baseline is a set of accepted findings, identified by fingerprints rather than positions
+// so that it survives unrelated changes of the code.
+type baseline struct {
+	Version  int             `json:"version"`
+	Findings []baselineEntry `json:"findings"`
+}
+
+// baselineEntry is a fingerprint of the baseline, with the number of findings it accepts.
+// Identical code in the same function shares a fingerprint, hence the count.
+type baselineEntry struct {
+	awspagination.Fingerprint
+	Count int `json:"count"`
+}
+
+// readBaseline reads the baseline file at path.
+// A missing file is an empty baseline, so that the first run with -write-baseline works.
+func readBaseline(path string) (*baseline, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &baseline{Version: baselineVersion}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var b baseline
+	if err := json.Unmarshal(content, &b); err != nil {
+		return nil, fmt.Errorf("parsing baseline %s: %w", path, err)
+	}
+	if b.Version != baselineVersion {
+		return nil, fmt.Errorf("unsupported baseline version %d in %s, want %d", b.Version, path, baselineVersion)
+	}
+	return &b, nil
+}
+
+// newBaseline returns a baseline accepting exactly the given fingerprints.
+func newBaseline(fingerprints []awspagination.Fingerprint) *baseline {
+	counts := make(map[awspagination.Fingerprint]int)
+	for _, fingerprint := range fingerprints {
+		counts[fingerprint]++
+	}
+
+	b := &baseline{Version: baselineVersion, Findings: []baselineEntry{}}
+	for fingerprint, count := range counts {
+		b.Findings = append(b.Findings, baselineEntry{Fingerprint: fingerprint, Count: count})
+	}
+	// Sorted so that the file only changes with the findings
+	slices.SortFunc(b.Findings, func(a, b baselineEntry) int {
+		return cmp.Or(
+			cmp.Compare(a.Package, b.Package),
+			cmp.Compare(a.Function, b.Function),
+			cmp.Compare(a.Service, b.Service),
+			cmp.Compare(a.Operation, b.Operation),
+			cmp.Compare(a.Variable, b.Variable),
+			cmp.Compare(a.Message, b.Message),
+		)
+	})
+	return b
+}
+
+// write writes the baseline to path.
+func (b *baseline) write(path string) error {
+	content, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(content, '\n'), 0o644)
+}
+
+// accepts reports for each of fingerprints whether the baseline accepts it. Each entry
+// accepts at most Count findings of its fingerprint, so that a copy of an accepted call
+// is still reported.
+func (b *baseline) accepts(fingerprints []awspagination.Fingerprint) []bool {
+	remaining := make(map[awspagination.Fingerprint]int)
+	for _, entry := range b.Findings {
+		remaining[entry.Fingerprint] += entry.Count
+	}
+
+	accepted := make([]bool, len(fingerprints))
+	for i, fingerprint := range fingerprints {
+		if remaining[fingerprint] > 0 {
+			remaining[fingerprint]--
+			accepted[i] = true
+		}
+	}
+	return accepted
+}
+
+// prune returns the baseline without the entries that no longer occur in fingerprints,
+// with counts lowered to the current number of findings.
+func (b *baseline) prune(fingerprints []awspagination.Fingerprint) *baseline {
+	var known []awspagination.Fingerprint
+	for i, accepted := range b.accepts(fingerprints) {
+		if accepted {
+			known = append(known, fingerprints[i])
+		}
+	}
+	return newBaseline(known)
+}
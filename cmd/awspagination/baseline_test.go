@@ -0,0 +1,120 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/koh-sh/awspagination"
+)
+
+// TestBaselineAccepts verifies that a baseline accepts as many findings of a fingerprint
+// as it recorded, regardless of their positions
+func TestBaselineAccepts(t *testing.T) {
+	listTasks := awspagination.Fingerprint{Package: "app", Function: "run", Service: "ecs", Operation: "ListTasks", Variable: "out", Message: "missing pagination"}
+	listServices := awspagination.Fingerprint{Package: "app", Function: "run", Service: "ecs", Operation: "ListServices", Variable: "out", Message: "missing pagination"}
+	renamed := listTasks
+	renamed.Function = "start"
+
+	b := newBaseline([]awspagination.Fingerprint{listTasks, listServices, listTasks})
+
+	tests := []struct {
+		name         string
+		fingerprints []awspagination.Fingerprint
+		want         []bool
+	}{
+		{name: "same findings", fingerprints: []awspagination.Fingerprint{listTasks, listServices, listTasks}, want: []bool{true, true, true}},
+		{name: "one more copy", fingerprints: []awspagination.Fingerprint{listTasks, listTasks, listTasks}, want: []bool{true, true, false}},
+		{name: "new function", fingerprints: []awspagination.Fingerprint{renamed, listServices}, want: []bool{false, true}},
+		{name: "no findings", fingerprints: nil, want: []bool{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.accepts(tt.fingerprints); !slices.Equal(got, tt.want) {
+				t.Errorf("accepts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestBaselinePrune verifies that pruning drops the entries that no longer occur
+func TestBaselinePrune(t *testing.T) {
+	listTasks := awspagination.Fingerprint{Package: "app", Service: "ecs", Operation: "ListTasks", Message: "missing pagination"}
+	listServices := awspagination.Fingerprint{Package: "app", Service: "ecs", Operation: "ListServices", Message: "missing pagination"}
+	listClusters := awspagination.Fingerprint{Package: "app", Service: "ecs", Operation: "ListClusters", Message: "missing pagination"}
+
+	b := newBaseline([]awspagination.Fingerprint{listTasks, listTasks, listServices})
+	pruned := b.prune([]awspagination.Fingerprint{listTasks, listClusters})
+
+	want := []baselineEntry{{Fingerprint: listTasks, Count: 1}}
+	if !slices.Equal(pruned.Findings, want) {
+		t.Errorf("prune() = %+v, want %+v", pruned.Findings, want)
+	}
+}
+
+// TestBaselineFile verifies reading and writing baseline files
+func TestBaselineFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "awspagination-baseline.json")
+
+	// A missing file is an empty baseline
+	b, err := readBaseline(path)
+	if err != nil {
+		t.Fatalf("readBaseline() of a missing file error = %v", err)
+	}
+	if len(b.Findings) != 0 {
+		t.Errorf("readBaseline() of a missing file = %+v, want no findings", b.Findings)
+	}
+
+	// Entries are sorted, so that the file only changes with the findings
+	listTasks := awspagination.Fingerprint{Package: "app", Function: "b", Message: "missing pagination"}
+	listServices := awspagination.Fingerprint{Package: "app", Function: "a", Message: "missing pagination"}
+	if err := newBaseline([]awspagination.Fingerprint{listTasks, listServices}).write(path); err != nil {
+		t.Fatalf("write() error = %v", err)
+	}
+	b, err = readBaseline(path)
+	if err != nil {
+		t.Fatalf("readBaseline() error = %v", err)
+	}
+	want := []baselineEntry{{Fingerprint: listServices, Count: 1}, {Fingerprint: listTasks, Count: 1}}
+	if !slices.Equal(b.Findings, want) {
+		t.Errorf("readBaseline() = %+v, want %+v", b.Findings, want)
+	}
+
+	// Invalid files are rejected
+	for _, content := range []string{"not json", `{"version": 2, "findings": []}`} {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := readBaseline(path); err == nil {
+			t.Errorf("readBaseline() of %q: expected error", content)
+		}
+	}
+}
+
+// TestUsesDriver verifies that the driver is only used with its flags
+func TestUsesDriver(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{args: []string{"./..."}, want: false},
+		{args: []string{"-strict-loops", "./..."}, want: false},
+		{args: []string{"-baseline=baseline.json", "./..."}, want: true},
+		{args: []string{"--baseline", "baseline.json", "./..."}, want: true},
+		{args: []string{"-baseline=baseline.json", "-write-baseline", "./..."}, want: true},
+		{args: []string{"-prune-baseline", "./..."}, want: true},
+		{args: []string{"-exclude-paths", "mocks/**", "-baseline=baseline.json", "./..."}, want: true},
+		{args: []string{"-format=sarif", "./..."}, want: true},
+		{args: []string{"-format", "json", "./..."}, want: true},
+		{args: []string{"-inventory=csv", "./..."}, want: true},
+		{args: []string{"--", "-baseline=baseline.json"}, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := usesDriver(tt.args); got != tt.want {
+			t.Errorf("usesDriver(%q) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/koh-sh/awspagination"
+	"golang.org/x/tools/go/analysis/checker"
+)
+
+// Output formats of -inventory.
+const (
+	inventoryCSV  = "csv"
+	inventoryJSON = "json"
+)
+
+// inventoryFormats are the valid values of -inventory.
+var inventoryFormats = []string{inventoryCSV, inventoryJSON}
+
+// callSite is a row of the inventory, see awspagination.CallSite.
+type callSite struct {
+	File        string   `json:"file"`
+	Line        int      `json:"line"`
+	Column      int      `json:"column"`
+	Package     string   `json:"package"`
+	Function    string   `json:"function,omitempty"`
+	Service     string   `json:"service"`
+	Operation   string   `json:"operation"`
+	Method      string   `json:"method,omitempty"`
+	Variable    string   `json:"variable,omitempty"`
+	TokenFields []string `json:"tokenFields"`
+	Status      string   `json:"status"`
+	Rule        string   `json:"rule,omitempty"`
+	Reason      string   `json:"reason,omitempty"`
+}
+
+// csvHeader is the header row of the CSV inventory, naming the fields of callSite.
+var csvHeader = []string{
+	"file", "line", "column", "package", "function", "service", "operation", "method",
+	"variable", "token_fields", "status", "rule", "reason",
+}
+
+// callSitesOf returns the call sites of the root packages of graph, once each and in
+// order of position, with file names relative to the working directory.
+func callSitesOf(graph *checker.Graph) []callSite {
+	seen := make(map[string]bool)
+	var sites []callSite
+	for _, act := range graph.Roots {
+		result, ok := act.Result.(*awspagination.Result)
+		if !ok {
+			continue
+		}
+		for _, site := range result.CallSites {
+			position := act.Package.Fset.Position(site.Pos)
+			if seen[position.String()] {
+				continue
+			}
+			seen[position.String()] = true
+
+			sites = append(sites, callSite{
+				File:        relativePath(position.Filename),
+				Line:        position.Line,
+				Column:      position.Column,
+				Package:     site.Package,
+				Function:    site.Function,
+				Service:     site.Service,
+				Operation:   site.Operation,
+				Method:      site.Method,
+				Variable:    site.Variable,
+				TokenFields: site.TokenFields,
+				Status:      string(site.Status),
+				Rule:        site.Rule,
+				Reason:      site.Reason,
+			})
+		}
+	}
+
+	slices.SortFunc(sites, func(a, b callSite) int {
+		return cmp.Or(
+			cmp.Compare(a.File, b.File),
+			cmp.Compare(a.Line, b.Line),
+			cmp.Compare(a.Column, b.Column),
+		)
+	})
+	return sites
+}
+
+// writeInventoryCSV writes sites to w as CSV with a header row. Token fields are
+// separated by spaces.
+func writeInventoryCSV(w io.Writer, sites []callSite) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, site := range sites {
+		err := writer.Write([]string{
+			site.File, strconv.Itoa(site.Line), strconv.Itoa(site.Column), site.Package, site.Function,
+			site.Service, site.Operation, site.Method, site.Variable, strings.Join(site.TokenFields, " "),
+			site.Status, site.Rule, site.Reason,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeInventoryJSON writes sites to w as a JSON object with a "callSites" array.
+func writeInventoryJSON(w io.Writer, sites []callSite) error {
+	if sites == nil {
+		sites = []callSite{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(struct {
+		CallSites []callSite `json:"callSites"`
+	}{sites})
+}
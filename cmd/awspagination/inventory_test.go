@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// TestRunInventory verifies the CSV and JSON inventories of the driver on the inventory testdata
+func TestRunInventory(t *testing.T) {
+	testdata, err := filepath.Abs(filepath.Join("..", "..", "testdata", "src", "test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(testdata)
+	t.Setenv("GOFLAGS", "-mod=vendor")
+	t.Setenv("GOPROXY", "off")
+
+	runInventory := func(t *testing.T, format string) []byte {
+		t.Helper()
+		var stdout, stderr bytes.Buffer
+		args := []string{"-inventory=" + format, "-limited-calls=ignore", "-exclude-operations=ecs.ListClusters", "./inventory"}
+		if code := run(args, &stdout, &stderr); code != 0 {
+			t.Fatalf("exit code %d, want 0; stderr:\n%s", code, stderr.String())
+		}
+		return stdout.Bytes()
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		records, err := csv.NewReader(bytes.NewReader(runInventory(t, inventoryCSV))).ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(records[0], csvHeader) {
+			t.Errorf("header %q, want %q", records[0], csvHeader)
+		}
+		if len(records) != 13 {
+			t.Fatalf("got %d rows, want 13", len(records))
+		}
+
+		want := []string{
+			"inventory/inventory.go", "89", "17", "test/inventory", "suppressed", "ecs", "ListServices", "ListServices",
+			"services", "NextToken", "suppressed", "missing-pagination", "only the first services are shown",
+		}
+		if !slices.Equal(records[9], want) {
+			t.Errorf("row %q, want %q", records[9], want)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var output struct {
+			CallSites []callSite `json:"callSites"`
+		}
+		if err := json.Unmarshal(runInventory(t, inventoryJSON), &output); err != nil {
+			t.Fatal(err)
+		}
+
+		var statuses []string
+		for _, site := range output.CallSites {
+			statuses = append(statuses, site.Status)
+		}
+		want := []string{
+			"paginator", "manual-loop", "helper", "returned", "limited", "excluded",
+			"discarded", "unhandled", "suppressed", "unhandled", "unhandled", "paginator",
+		}
+		if !slices.Equal(statuses, want) {
+			t.Errorf("statuses %q, want %q", statuses, want)
+		}
+	})
+}
+
+// TestRunInventoryFlags verifies that -inventory is not combined with the other driver flags
+func TestRunInventoryFlags(t *testing.T) {
+	tests := [][]string{
+		{"-inventory=xml", "./..."},
+		{"-inventory=csv", "-format=json", "./..."},
+		{"-inventory=json", "-baseline=baseline.json", "./..."},
+	}
+
+	for _, args := range tests {
+		var stdout, stderr bytes.Buffer
+		if code := run(args, &stdout, &stderr); code != 1 {
+			t.Errorf("run(%q) = %d, want 1", args, code)
+		}
+		if stderr.Len() == 0 {
+			t.Errorf("run(%q) printed no error", args)
+		}
+	}
+}
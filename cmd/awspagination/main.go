@@ -1,10 +1,279 @@
 package main
 
 import (
+	"cmp"
+	"flag"
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
 	"github.com/koh-sh/awspagination"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
 	"golang.org/x/tools/go/analysis/singlechecker"
+	"golang.org/x/tools/go/packages"
 )
 
+// driverFlags are the flags handled by run instead of singlechecker.
+var driverFlags = []string{"format", "inventory", "baseline", "write-baseline", "prune-baseline"}
+
 func main() {
-	singlechecker.Main(awspagination.Analyzer)
+	if !usesDriver(os.Args[1:]) {
+		singlechecker.Main(awspagination.Analyzer)
+		return
+	}
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// usesDriver reports whether args contain one of driverFlags.
+// Flag values are not told apart from flags, which is fine since none starts with a dash.
+func usesDriver(args []string) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		name, _, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if slices.Contains(driverFlags, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// run analyzes the packages of args like singlechecker, and prints the diagnostics to
+// stderr in text format or to stdout in the machine-readable format given by -format.
+// With -baseline, only the findings not accepted by the baseline file are reported, or
+// that file is written or pruned. With -inventory, the call sites of the packages are
+// listed on stdout instead of the diagnostics.
+// It returns the exit code of the command: 0 if nothing is reported, 3 if diagnostics
+// are reported, and 1 on errors.
+func run(args []string, stdout, stderr io.Writer) int {
+	// The flags of the analyzer are bound to an analyzer of its own, so that each run
+	// starts from the default configuration
+	analyzer := awspagination.NewAnalyzer(awspagination.Config{})
+	flags := flag.NewFlagSet(analyzer.Name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", formatText, "output format: "+strings.Join(formats, ", "))
+	inventory := flags.String("inventory", "", "list every paginated AWS SDK call site with its status instead of reporting findings: "+strings.Join(inventoryFormats, ", "))
+	path := flags.String("baseline", "", "report only findings not in this baseline file")
+	write := flags.Bool("write-baseline", false, "write the current findings to the -baseline file instead of reporting them")
+	prune := flags.Bool("prune-baseline", false, "remove the findings that no longer occur from the -baseline file")
+	tests := flags.Bool("test", true, "indicates whether test files should be analyzed, too")
+	analyzer.Flags.VisitAll(func(f *flag.Flag) {
+		flags.Var(f.Value, f.Name, f.Usage)
+	})
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s [-format=%s] [-baseline=file [-write-baseline | -prune-baseline]] [flags] [packages]\n",
+			analyzer.Name, strings.Join(formats, "|"))
+		fmt.Fprintf(flags.Output(), "       %s -inventory=%s [flags] [packages]\n",
+			analyzer.Name, strings.Join(inventoryFormats, "|"))
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	set := make(map[string]bool)
+	flags.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	switch {
+	case *inventory != "" && !slices.Contains(inventoryFormats, *inventory):
+		fmt.Fprintf(stderr, "invalid -inventory %q: must be one of %s\n", *inventory, strings.Join(inventoryFormats, ", "))
+		return 1
+	case *inventory != "" && (set["format"] || set["baseline"] || set["write-baseline"] || set["prune-baseline"]):
+		fmt.Fprintln(stderr, "-inventory cannot be combined with -format or the baseline flags")
+		return 1
+	case !slices.Contains(formats, *format):
+		fmt.Fprintf(stderr, "invalid -format %q: must be one of %s\n", *format, strings.Join(formats, ", "))
+		return 1
+	case (*write || *prune) && *path == "":
+		fmt.Fprintln(stderr, "-write-baseline and -prune-baseline require -baseline")
+		return 1
+	case *write && *prune:
+		fmt.Fprintln(stderr, "-write-baseline and -prune-baseline are mutually exclusive")
+		return 1
+	}
+
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax, Tests: *tests}, flags.Args()...)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return 1
+	}
+
+	graph, err := checker.Analyze([]*analysis.Analyzer{analyzer}, pkgs, nil)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	if *inventory != "" {
+		sites := callSitesOf(graph)
+		if *inventory == inventoryCSV {
+			err = writeInventoryCSV(stdout, sites)
+		} else {
+			err = writeInventoryJSON(stdout, sites)
+		}
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		if printErrors(stderr, graph) {
+			return 1
+		}
+		return 0
+	}
+
+	findings := collectFindings(graph)
+
+	if *path != "" {
+		fingerprints := make([]awspagination.Fingerprint, len(findings))
+		for i, finding := range findings {
+			fingerprints[i] = finding.Fingerprint
+		}
+
+		if *write {
+			if err := newBaseline(fingerprints).write(*path); err != nil {
+				fmt.Fprintln(stderr, err)
+				return 1
+			}
+			fmt.Fprintf(stderr, "wrote %d findings to %s\n", len(fingerprints), *path)
+			return 0
+		}
+
+		b, err := readBaseline(*path)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		if *prune {
+			if err := b.prune(fingerprints).write(*path); err != nil {
+				fmt.Fprintln(stderr, err)
+				return 1
+			}
+		}
+
+		// Findings accepted by the baseline are dropped from every package they were
+		// reported in (e.g., both p and p [p.test])
+		accepted := make(map[findingKey]bool)
+		for i, ok := range b.accepts(fingerprints) {
+			if ok {
+				accepted[findings[i].key] = true
+			}
+		}
+		for act := range graph.All() {
+			if act.IsRoot && act.Analyzer == analyzer {
+				act.Diagnostics = slices.DeleteFunc(act.Diagnostics, func(diagnostic analysis.Diagnostic) bool {
+					return accepted[keyOf(act.Package.Fset, diagnostic)]
+				})
+			}
+		}
+	}
+
+	reported := false
+	for act := range graph.All() {
+		if act.IsRoot && len(act.Diagnostics) > 0 {
+			reported = true
+		}
+	}
+
+	switch *format {
+	case formatJSON:
+		err = writeJSON(stdout, diagnosticsOf(graph, findings))
+	case formatSARIF:
+		err = writeSARIF(stdout, diagnosticsOf(graph, findings))
+	default:
+		err = graph.PrintText(stderr, -1)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	// Errors of the analysis are printed along with the diagnostics in text format only
+	failed := false
+	if *format == formatText {
+		for act := range graph.All() {
+			failed = failed || act.Err != nil
+		}
+	} else {
+		failed = printErrors(stderr, graph)
+	}
+
+	switch {
+	case failed:
+		return 1
+	case reported:
+		return 3
+	}
+	return 0
+}
+
+// printErrors prints the errors of the actions of graph to w, and reports whether there
+// are any.
+func printErrors(w io.Writer, graph *checker.Graph) bool {
+	failed := false
+	for act := range graph.All() {
+		if act.Err != nil {
+			fmt.Fprintf(w, "%s: %v\n", act, act.Err)
+			failed = true
+		}
+	}
+	return failed
+}
+
+// findingKey identifies a finding reported in several packages sharing a file.
+type findingKey struct {
+	position token.Position
+	message  string
+}
+
+func keyOf(fset *token.FileSet, diagnostic analysis.Diagnostic) findingKey {
+	return findingKey{position: fset.Position(diagnostic.Pos), message: diagnostic.Message}
+}
+
+// finding is a finding of the analyzer reported in at least one package.
+type finding struct {
+	awspagination.Finding
+	key findingKey
+}
+
+// collectFindings returns the findings of the root packages of graph, once each and in
+// order of position.
+func collectFindings(graph *checker.Graph) []finding {
+	seen := make(map[findingKey]bool)
+	var findings []finding
+	for _, act := range graph.Roots {
+		result, ok := act.Result.(*awspagination.Result)
+		if !ok {
+			continue
+		}
+		for _, f := range result.Findings {
+			key := keyOf(act.Package.Fset, f.Diagnostic)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			findings = append(findings, finding{Finding: f, key: key})
+		}
+	}
+
+	slices.SortFunc(findings, func(a, b finding) int {
+		return cmp.Or(
+			cmp.Compare(a.key.position.Filename, b.key.position.Filename),
+			cmp.Compare(a.key.position.Offset, b.key.position.Offset),
+			cmp.Compare(a.key.message, b.key.message),
+		)
+	})
+	return findings
 }
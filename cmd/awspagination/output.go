@@ -0,0 +1,363 @@
+package main
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/koh-sh/awspagination"
+	"golang.org/x/tools/go/analysis/checker"
+)
+
+// Output formats of -format.
+const (
+	formatText  = "text"
+	formatJSON  = "json"
+	formatSARIF = "sarif"
+)
+
+// formats are the valid values of -format.
+var formats = []string{formatText, formatJSON, formatSARIF}
+
+// informationURI is the documentation of the analyzer, linked from SARIF output.
+const informationURI = "https://github.com/koh-sh/awspagination"
+
+// position is a position in a file of the JSON output.
+// Line and column are 1-based, and the column and offset are counted in bytes.
+type position struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+	Offset int `json:"offset"`
+}
+
+// sourceRange is a range of source text of the JSON output.
+type sourceRange struct {
+	Start position `json:"start"`
+	End   position `json:"end"`
+}
+
+// textEdit is an edit of a suggested fix of the JSON output.
+type textEdit struct {
+	File    string      `json:"file"`
+	Range   sourceRange `json:"range"`
+	NewText string      `json:"newText"`
+}
+
+// suggestedFix is a suggested fix of the JSON output.
+type suggestedFix struct {
+	Message string     `json:"message"`
+	Edits   []textEdit `json:"edits"`
+}
+
+// diagnostic is a diagnostic of the analyzer with the details of its finding, if any.
+// Diagnostics about directives have no finding, so they only carry the location.
+type diagnostic struct {
+	Rule           string         `json:"rule"`
+	Message        string         `json:"message"`
+	File           string         `json:"file"`
+	Range          sourceRange    `json:"range"`
+	Package        string         `json:"package,omitempty"`
+	Function       string         `json:"function,omitempty"`
+	Service        string         `json:"service,omitempty"`
+	Operation      string         `json:"operation,omitempty"`
+	Variable       string         `json:"variable,omitempty"`
+	TokenFields    []string       `json:"tokenFields,omitempty"`
+	SuggestedFixes []suggestedFix `json:"suggestedFixes,omitempty"`
+
+	// fingerprint identifies the finding across changes of its position.
+	fingerprint *awspagination.Fingerprint
+}
+
+// diagnosticsOf returns the diagnostics of the root packages of graph, once each and in
+// order of position, completed with the details of findings.
+func diagnosticsOf(graph *checker.Graph, findings []finding) []diagnostic {
+	byKey := make(map[findingKey]finding, len(findings))
+	for _, f := range findings {
+		byKey[f.key] = f
+	}
+
+	seen := make(map[findingKey]bool)
+	var diagnostics []diagnostic
+	for act := range graph.All() {
+		if !act.IsRoot {
+			continue
+		}
+		fset := act.Package.Fset
+		for _, d := range act.Diagnostics {
+			key := keyOf(fset, d)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			diag := diagnostic{
+				Rule:    d.Category,
+				Message: d.Message,
+				File:    key.position.Filename,
+				Range:   rangeOf(fset, d.Pos, d.End),
+			}
+			if f, ok := byKey[key]; ok {
+				diag.Package = f.Fingerprint.Package
+				diag.Function = f.Fingerprint.Function
+				diag.Service = f.Fingerprint.Service
+				diag.Operation = f.Fingerprint.Operation
+				diag.Variable = f.Fingerprint.Variable
+				diag.TokenFields = f.TokenFields
+				diag.fingerprint = &f.Fingerprint
+			}
+			for _, fix := range d.SuggestedFixes {
+				sf := suggestedFix{Message: fix.Message, Edits: []textEdit{}}
+				for _, edit := range fix.TextEdits {
+					sf.Edits = append(sf.Edits, textEdit{
+						File:    fset.Position(edit.Pos).Filename,
+						Range:   rangeOf(fset, edit.Pos, edit.End),
+						NewText: string(edit.NewText),
+					})
+				}
+				diag.SuggestedFixes = append(diag.SuggestedFixes, sf)
+			}
+			diagnostics = append(diagnostics, diag)
+		}
+	}
+
+	slices.SortFunc(diagnostics, func(a, b diagnostic) int {
+		return cmp.Or(
+			cmp.Compare(a.File, b.File),
+			cmp.Compare(a.Range.Start.Offset, b.Range.Start.Offset),
+			cmp.Compare(a.Message, b.Message),
+		)
+	})
+	return diagnostics
+}
+
+// rangeOf returns the range from pos to end, which is empty if end is not set.
+func rangeOf(fset *token.FileSet, pos, end token.Pos) sourceRange {
+	start := positionOf(fset.Position(pos))
+	if !end.IsValid() {
+		return sourceRange{Start: start, End: start}
+	}
+	return sourceRange{Start: start, End: positionOf(fset.Position(end))}
+}
+
+func positionOf(p token.Position) position {
+	return position{Line: p.Line, Column: p.Column, Offset: p.Offset}
+}
+
+// writeJSON writes diagnostics to w as a JSON object with a "diagnostics" array.
+func writeJSON(w io.Writer, diagnostics []diagnostic) error {
+	if diagnostics == nil {
+		diagnostics = []diagnostic{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(struct {
+		Diagnostics []diagnostic `json:"diagnostics"`
+	}{diagnostics})
+}
+
+// SARIF 2.1.0 log, limited to the properties written by writeSARIF.
+// See https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html.
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string                 `json:"id"`
+	ShortDescription     sarifMessage           `json:"shortDescription"`
+	DefaultConfiguration sarifRuleConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifRuleConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	Fixes               []sarifFix        `json:"fixes,omitempty"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+	Properties          *sarifProperties  `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// sarifRegion is a range of lines and columns. Columns are counted in bytes like in
+// go/token, which matches the code points SARIF counts for ASCII source.
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine"`
+	EndColumn   int `json:"endColumn"`
+}
+
+type sarifFix struct {
+	Description     sarifMessage          `json:"description"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   sarifRegion   `json:"deletedRegion"`
+	InsertedContent *sarifMessage `json:"insertedContent,omitempty"`
+}
+
+type sarifProperties struct {
+	Service     string   `json:"service,omitempty"`
+	Operation   string   `json:"operation,omitempty"`
+	TokenFields []string `json:"tokenFields,omitempty"`
+}
+
+// fingerprintKey is the key of the partial fingerprint of results, stable across changes
+// of their position (see awspagination.Fingerprint).
+const fingerprintKey = "awspagination/v1"
+
+// writeSARIF writes diagnostics to w as a SARIF 2.1.0 log, with file URIs relative to the
+// working directory.
+func writeSARIF(w io.Writer, diagnostics []diagnostic) error {
+	driver := sarifDriver{Name: awspagination.Analyzer.Name, InformationURI: informationURI}
+	levels := make(map[string]string)
+	for _, rule := range awspagination.Rules {
+		level := "warning"
+		if rule.Informational {
+			level = "note"
+		}
+		levels[rule.ID] = level
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:                   rule.ID,
+			ShortDescription:     sarifMessage{Text: rule.Description},
+			DefaultConfiguration: sarifRuleConfiguration{Level: level},
+		})
+	}
+
+	results := []sarifResult{}
+	for _, d := range diagnostics {
+		result := sarifResult{
+			RuleID:  d.Rule,
+			Level:   cmp.Or(levels[d.Rule], "warning"),
+			Message: sarifMessage{Text: d.Message},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: uriOf(d.File)},
+					Region:           regionOf(d.Range),
+				},
+			}},
+		}
+		for _, fix := range d.SuggestedFixes {
+			result.Fixes = append(result.Fixes, sarifFixOf(fix))
+		}
+		if d.fingerprint != nil {
+			result.PartialFingerprints = map[string]string{fingerprintKey: hashOf(*d.fingerprint)}
+		}
+		if d.Service != "" || d.Operation != "" || len(d.TokenFields) > 0 {
+			result.Properties = &sarifProperties{Service: d.Service, Operation: d.Operation, TokenFields: d.TokenFields}
+		}
+		results = append(results, result)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	})
+}
+
+// sarifFixOf returns fix with its edits grouped by file, in order of first edit.
+func sarifFixOf(fix suggestedFix) sarifFix {
+	result := sarifFix{Description: sarifMessage{Text: fix.Message}}
+	changes := make(map[string]int)
+	for _, edit := range fix.Edits {
+		i, ok := changes[edit.File]
+		if !ok {
+			i = len(result.ArtifactChanges)
+			changes[edit.File] = i
+			result.ArtifactChanges = append(result.ArtifactChanges, sarifArtifactChange{
+				ArtifactLocation: sarifArtifactLocation{URI: uriOf(edit.File)},
+			})
+		}
+		replacement := sarifReplacement{DeletedRegion: regionOf(edit.Range)}
+		if edit.NewText != "" {
+			replacement.InsertedContent = &sarifMessage{Text: edit.NewText}
+		}
+		result.ArtifactChanges[i].Replacements = append(result.ArtifactChanges[i].Replacements, replacement)
+	}
+	return result
+}
+
+func regionOf(r sourceRange) sarifRegion {
+	return sarifRegion{
+		StartLine:   r.Start.Line,
+		StartColumn: r.Start.Column,
+		EndLine:     r.End.Line,
+		EndColumn:   r.End.Column,
+	}
+}
+
+// uriOf returns the URI of filename relative to the working directory, see relativePath.
+func uriOf(filename string) string {
+	return filepath.ToSlash(relativePath(filename))
+}
+
+// relativePath returns filename relative to the working directory, or filename as is if
+// it is not below the working directory.
+func relativePath(filename string) string {
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, filename); err == nil && filepath.IsLocal(rel) {
+			return rel
+		}
+	}
+	return filename
+}
+
+// hashOf returns the hex-encoded SHA-256 hash of the JSON encoding of fingerprint.
+func hashOf(fingerprint awspagination.Fingerprint) string {
+	data, _ := json.Marshal(fingerprint)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
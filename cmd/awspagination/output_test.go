@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/koh-sh/awspagination"
+)
+
+// TestRunFormats verifies the JSON and SARIF output of the driver on the fingerprint testdata
+func TestRunFormats(t *testing.T) {
+	testdata, err := filepath.Abs(filepath.Join("..", "..", "testdata", "src", "test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(testdata)
+	t.Setenv("GOFLAGS", "-mod=vendor")
+	t.Setenv("GOPROXY", "off")
+
+	runFormat := func(t *testing.T, format string) []byte {
+		t.Helper()
+		var stdout, stderr bytes.Buffer
+		if code := run([]string{"-format=" + format, "./fingerprint"}, &stdout, &stderr); code != 3 {
+			t.Fatalf("exit code %d, want 3; stderr:\n%s", code, stderr.String())
+		}
+		return stdout.Bytes()
+	}
+
+	t.Run("json", func(t *testing.T) {
+		var output struct {
+			Diagnostics []diagnostic `json:"diagnostics"`
+		}
+		if err := json.Unmarshal(runFormat(t, formatJSON), &output); err != nil {
+			t.Fatal(err)
+		}
+		if len(output.Diagnostics) != 5 {
+			t.Fatalf("got %d diagnostics, want 5", len(output.Diagnostics))
+		}
+
+		d := output.Diagnostics[0]
+		if d.Rule != "missing-pagination" || d.Function != "lister.listTasks" || d.Service != "ecs" ||
+			d.Operation != "ListTasks" || d.Variable != "tasks" || !slices.Equal(d.TokenFields, []string{"NextToken"}) {
+			t.Errorf("unexpected first diagnostic %+v", d)
+		}
+		if d.File != filepath.Join(testdata, "fingerprint", "fingerprint.go") || d.Range.Start.Line != 18 || d.Range.Start.Column != 14 {
+			t.Errorf("first diagnostic at %s:%d:%d, want fingerprint/fingerprint.go:18:14", d.File, d.Range.Start.Line, d.Range.Start.Column)
+		}
+		if len(d.SuggestedFixes) == 0 || len(d.SuggestedFixes[0].Edits) == 0 {
+			t.Errorf("first diagnostic has no suggested fix edits")
+		}
+		if rule := output.Diagnostics[3].Rule; rule != "incomplete-iteration" {
+			t.Errorf("fourth diagnostic of rule %q, want incomplete-iteration", rule)
+		}
+		if rule := output.Diagnostics[4].Rule; rule != "unused-directive" {
+			t.Errorf("last diagnostic of rule %q, want unused-directive", rule)
+		}
+	})
+
+	t.Run("sarif", func(t *testing.T) {
+		var log sarifLog
+		if err := json.Unmarshal(runFormat(t, formatSARIF), &log); err != nil {
+			t.Fatal(err)
+		}
+		if log.Version != "2.1.0" || len(log.Runs) != 1 {
+			t.Fatalf("unexpected log version %q with %d runs", log.Version, len(log.Runs))
+		}
+		r := log.Runs[0]
+		if len(r.Tool.Driver.Rules) != len(awspagination.Rules) {
+			t.Errorf("got %d rules, want %d", len(r.Tool.Driver.Rules), len(awspagination.Rules))
+		}
+		if len(r.Results) != 5 {
+			t.Fatalf("got %d results, want 5", len(r.Results))
+		}
+
+		result := r.Results[0]
+		if uri := result.Locations[0].PhysicalLocation.ArtifactLocation.URI; uri != "fingerprint/fingerprint.go" {
+			t.Errorf("result at %q, want fingerprint/fingerprint.go", uri)
+		}
+		if result.RuleID != "missing-pagination" || result.Level != "warning" {
+			t.Errorf("result of rule %q and level %q, want missing-pagination and warning", result.RuleID, result.Level)
+		}
+		if result.PartialFingerprints[fingerprintKey] == "" || len(result.Fixes) == 0 {
+			t.Errorf("result without fingerprint or fixes: %+v", result)
+		}
+		if result.Properties == nil || result.Properties.Operation != "ListTasks" {
+			t.Errorf("result properties %+v, want operation ListTasks", result.Properties)
+		}
+	})
+}
+
+// TestWriteSARIF verifies the levels, fingerprints and fixes of SARIF results
+func TestWriteSARIF(t *testing.T) {
+	fingerprint := awspagination.Fingerprint{Package: "app", Function: "run", Service: "ecs", Operation: "ListTasks", Message: "info: limited"}
+	moved := fingerprint
+	diagnostics := []diagnostic{
+		{
+			Rule: "limited-call", Message: "info: limited", File: "app.go",
+			Range:       sourceRange{Start: position{Line: 3, Column: 2}, End: position{Line: 3, Column: 2}},
+			Service:     "ecs",
+			Operation:   "ListTasks",
+			fingerprint: &fingerprint,
+		},
+		{
+			Rule: "unused-directive", Message: "unused directive", File: "app.go",
+			Range: sourceRange{Start: position{Line: 10, Column: 1}, End: position{Line: 10, Column: 30}},
+			SuggestedFixes: []suggestedFix{{
+				Message: "Remove the directive",
+				Edits: []textEdit{
+					{File: "app.go", Range: sourceRange{Start: position{Line: 10, Column: 1}, End: position{Line: 10, Column: 30}}},
+					{File: "other.go", Range: sourceRange{Start: position{Line: 1, Column: 1}, End: position{Line: 1, Column: 1}}, NewText: "x"},
+					{File: "app.go", Range: sourceRange{Start: position{Line: 12, Column: 1}, End: position{Line: 12, Column: 1}}, NewText: "y"},
+				},
+			}},
+		},
+		{
+			Rule: "limited-call", Message: "info: limited", File: "app.go",
+			Range:       sourceRange{Start: position{Line: 20, Column: 2}, End: position{Line: 20, Column: 2}},
+			fingerprint: &moved,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeSARIF(&buf, diagnostics); err != nil {
+		t.Fatal(err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+	results := log.Runs[0].Results
+
+	if results[0].Level != "note" || results[1].Level != "warning" {
+		t.Errorf("levels %q and %q, want note and warning", results[0].Level, results[1].Level)
+	}
+	if results[0].PartialFingerprints[fingerprintKey] != results[2].PartialFingerprints[fingerprintKey] {
+		t.Errorf("fingerprints of the same finding at different positions differ")
+	}
+	if results[1].PartialFingerprints != nil || results[1].Properties != nil {
+		t.Errorf("directive result has fingerprints or properties: %+v", results[1])
+	}
+
+	changes := results[1].Fixes[0].ArtifactChanges
+	if len(changes) != 2 || changes[0].ArtifactLocation.URI != "app.go" || len(changes[0].Replacements) != 2 {
+		t.Fatalf("edits not grouped by file: %+v", changes)
+	}
+	if changes[0].Replacements[0].InsertedContent != nil || changes[0].Replacements[1].InsertedContent.Text != "y" {
+		t.Errorf("unexpected replacements %+v", changes[0].Replacements)
+	}
+}
+
+// TestWriteJSONEmpty verifies that no diagnostics are written as an empty array
+func TestWriteJSONEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "{\n  \"diagnostics\": []\n}\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -1,18 +1,19 @@
 package awspagination
 
 import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
+
+	"golang.org/x/tools/go/analysis"
 )
 
 // TestGetPaginationTokenFields verifies that custom token fields are added to defaults
 func TestGetPaginationTokenFields(t *testing.T) {
-	// Save original config
-	originalConfig := config
-	defer func() { config = originalConfig }()
-
 	// Test with no custom fields
-	config = Config{}
-	fields := getPaginationTokenFields()
+	fields := getPaginationTokenFields(&Config{})
 	if len(fields) != len(defaultPaginationTokenFields) {
 		t.Errorf("getPaginationTokenFields() without custom fields = %d fields, want %d",
 			len(fields), len(defaultPaginationTokenFields))
@@ -35,10 +36,9 @@ func TestGetPaginationTokenFields(t *testing.T) {
 	}
 
 	// Test with custom fields
-	config = Config{
+	fields = getPaginationTokenFields(&Config{
 		CustomTokenFields: stringSliceFlag{"CustomToken", "MyPageToken"},
-	}
-	fields = getPaginationTokenFields()
+	})
 	expectedTotal := len(defaultPaginationTokenFields) + 2
 	if len(fields) != expectedTotal {
 		t.Errorf("getPaginationTokenFields() with 2 custom fields = %d fields, want %d",
@@ -91,6 +91,11 @@ func TestExtractServiceNameFromPackage(t *testing.T) {
 			pkgPath: "github.com/mycompany/aws-sdk-go-v2/service/ec2",
 			want:    "ec2",
 		},
+		{
+			name:    "AWS SDK v1",
+			pkgPath: "github.com/aws/aws-sdk-go/service/iam",
+			want:    "iam",
+		},
 		{
 			name:    "non-AWS package",
 			pkgPath: "github.com/some/other/package",
@@ -109,7 +114,7 @@ func TestExtractServiceNameFromPackage(t *testing.T) {
 	}
 }
 
-// TestIsAWSSDKPackage verifies AWS SDK v2 package detection
+// TestIsAWSSDKPackage verifies AWS SDK v2 and v1 package detection
 func TestIsAWSSDKPackage(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -149,6 +154,11 @@ func TestIsAWSSDKPackage(t *testing.T) {
 		{
 			name:    "AWS SDK v1",
 			pkgPath: "github.com/aws/aws-sdk-go/service/s3",
+			want:    true,
+		},
+		{
+			name:    "AWS SDK v1 non-service package",
+			pkgPath: "github.com/aws/aws-sdk-go/aws/request",
 			want:    false,
 		},
 	}
@@ -224,10 +234,6 @@ func TestStringSliceFlag(t *testing.T) {
 
 // TestNew verifies the New function for module plugin integration
 func TestNew(t *testing.T) {
-	// Save original config
-	originalConfig := config
-	defer func() { config = originalConfig }()
-
 	tests := []struct {
 		name     string
 		settings any
@@ -237,12 +243,26 @@ func TestNew(t *testing.T) {
 		{
 			name: "valid settings with custom fields",
 			settings: map[string]any{
-				"custom-fields": []any{"MyToken", "CustomNextToken"},
-				"include-tests": true,
+				"custom-fields":    []any{"MyToken", "CustomNextToken"},
+				"include-tests":    true,
+				"strict-loops":     true,
+				"limited-calls":    "info",
+				"strict-token-use": true,
+				"service-fields": map[string]any{
+					"CloudWatchLogs": []any{"NextForwardToken"},
+				},
+				"explain": true,
 			},
 			want: Settings{
-				CustomFields: []string{"MyToken", "CustomNextToken"},
-				IncludeTests: true,
+				CustomFields:   []string{"MyToken", "CustomNextToken"},
+				IncludeTests:   true,
+				StrictLoops:    true,
+				LimitedCalls:   "info",
+				StrictTokenUse: true,
+				Explain:        true,
+				ServiceFields: map[string][]string{
+					"cloudwatchlogs": {"NextForwardToken"},
+				},
 			},
 			wantErr: false,
 		},
@@ -266,13 +286,38 @@ func TestNew(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "invalid service field",
+			settings: map[string]any{
+				"service-fields": map[string]any{"cloudwatchlogs": []any{"Next-Token"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid path pattern",
+			settings: map[string]any{
+				"exclude-paths": []any{"internal/[mocks"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid operation pattern",
+			settings: map[string]any{
+				"exclude-operations": []any{"ecs.["},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid limited-calls policy",
+			settings: map[string]any{
+				"limited-calls": "warn",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Reset config before each test
-			config = Config{}
-
 			analyzers, err := New(tt.settings)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
@@ -289,27 +334,214 @@ func TestNew(t *testing.T) {
 				return
 			}
 
-			if analyzers[0] != Analyzer {
-				t.Error("New() did not return the expected Analyzer")
+			if analyzers[0] == Analyzer {
+				t.Error("New() returned the shared Analyzer, want a new instance")
 			}
 
-			// Verify that config was updated correctly
-			if len(config.CustomTokenFields) != len(tt.want.CustomFields) {
-				t.Errorf("config.CustomTokenFields length = %d, want %d",
-					len(config.CustomTokenFields), len(tt.want.CustomFields))
+			// Verify that the settings were applied to the returned analyzer only,
+			// as exposed through its flags
+			flag := func(analyzer *analysis.Analyzer, name string) string {
+				return analyzer.Flags.Lookup(name).Value.String()
 			}
-
-			for i, field := range tt.want.CustomFields {
-				if i < len(config.CustomTokenFields) && config.CustomTokenFields[i] != field {
-					t.Errorf("config.CustomTokenFields[%d] = %q, want %q",
-						i, config.CustomTokenFields[i], field)
+			want := map[string]string{
+				"custom-fields":    strings.Join(tt.want.CustomFields, ","),
+				"include-tests":    strconv.FormatBool(tt.want.IncludeTests),
+				"strict-loops":     strconv.FormatBool(tt.want.StrictLoops),
+				"limited-calls":    cmp.Or(tt.want.LimitedCalls, "report"),
+				"service-field":    (*serviceFieldsFlag)(&tt.want.ServiceFields).String(),
+				"explain":          strconv.FormatBool(tt.want.Explain),
+				"strict-token-use": strconv.FormatBool(tt.want.StrictTokenUse),
+			}
+			for name, value := range want {
+				if got := flag(analyzers[0], name); got != value {
+					t.Errorf("-%s = %q, want %q", name, got, value)
+				}
+				if got, def := flag(Analyzer, name), Analyzer.Flags.Lookup(name).DefValue; got != def {
+					t.Errorf("Analyzer -%s = %q, want default %q", name, got, def)
 				}
 			}
+		})
+	}
+}
+
+// TestLimitPolicy verifies that only the known policies are accepted
+func TestLimitPolicy(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    limitPolicy
+		wantErr bool
+	}{
+		{value: "report", want: limitPolicyReport},
+		{value: "info", want: limitPolicyInfo},
+		{value: "ignore", want: limitPolicyIgnore},
+		{value: "", wantErr: true},
+		{value: "INFO", wantErr: true},
+		{value: "warn", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			var policy limitPolicy
+			err := policy.Set(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Set(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && policy != tt.want {
+				t.Errorf("Set(%q) = %q, want %q", tt.value, policy, tt.want)
+			}
+		})
+	}
+
+	// The zero value is the default policy
+	var policy limitPolicy
+	if policy.String() != "report" {
+		t.Errorf("zero limitPolicy String() = %q, want %q", policy.String(), "report")
+	}
+}
+
+// TestServiceFieldsFlag verifies parsing of repeated service:Field flags
+func TestServiceFieldsFlag(t *testing.T) {
+	var flag serviceFieldsFlag
+	for _, value := range []string{"CloudWatchLogs:NextForwardToken", "cloudwatchlogs:NextBackwardToken,NextForwardToken", "dynamodb:LastEvaluatedBackupArn"} {
+		if err := flag.Set(value); err != nil {
+			t.Fatalf("Set(%q) error = %v", value, err)
+		}
+	}
+
+	want := "cloudwatchlogs:NextForwardToken,NextBackwardToken dynamodb:LastEvaluatedBackupArn"
+	if got := flag.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	for _, value := range []string{"", "cloudwatchlogs", "cloudwatchlogs:", ":NextForwardToken", "cloudwatchlogs:Next-Token", "cloudwatchlogs:NextForwardToken,"} {
+		var flag serviceFieldsFlag
+		if err := flag.Set(value); err == nil {
+			t.Errorf("Set(%q): expected error", value)
+		}
+	}
+}
+
+// TestGetServiceTokenFields verifies that configured service fields add services and
+// are merged with the built-in fields of a service
+func TestGetServiceTokenFields(t *testing.T) {
+	cfg := &Config{
+		ServiceTokenFields: serviceFieldsFlag{
+			"cloudwatchlogs": {"NextForwardToken"},
+			"dynamodb":       {"LastEvaluatedBackupArn", "LastEvaluatedKey"},
+		},
+	}
+
+	tests := []struct {
+		service string
+		want    []string
+		wantOK  bool
+	}{
+		{service: "cloudwatchlogs", want: []string{"NextForwardToken"}, wantOK: true},
+		{service: "CloudWatchLogs", want: []string{"NextForwardToken"}, wantOK: true},
+		{service: "dynamodb", want: []string{"LastEvaluatedKey", "LastEvaluatedBackupArn"}, wantOK: true},
+		{service: "apigateway", want: []string{"Position"}, wantOK: true},
+		{service: "ecs", want: nil, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.service, func(t *testing.T) {
+			got, ok := getServiceTokenFields(cfg, tt.service)
+			if ok != tt.wantOK || !slices.Equal(got, tt.want) {
+				t.Errorf("getServiceTokenFields(%q) = %v, %v, want %v, %v", tt.service, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
 
-			if config.IncludeTests != tt.want.IncludeTests {
-				t.Errorf("config.IncludeTests = %v, want %v",
-					config.IncludeTests, tt.want.IncludeTests)
+	// The built-in fields are left untouched
+	if got, _ := getServiceTokenFields(&Config{}, "dynamodb"); !slices.Equal(got, []string{"LastEvaluatedKey"}) {
+		t.Errorf("getServiceTokenFields(dynamodb) without configuration = %v, want [LastEvaluatedKey]", got)
+	}
+}
+
+// TestMatchesOperation verifies matching of operation patterns against API calls
+func TestMatchesOperation(t *testing.T) {
+	listClusters := apiCallInfo{methodName: "ListClusters", serviceName: "ecs", typeName: "ListClustersOutput"}
+	listUsersV1 := apiCallInfo{methodName: "ListUsersWithContext", serviceName: "iam", typeName: "ListUsersOutput", sdkV1: true}
+
+	tests := []struct {
+		name     string
+		patterns []string
+		info     apiCallInfo
+		want     bool
+	}{
+		{name: "service and operation", patterns: []string{"ecs.ListClusters"}, info: listClusters, want: true},
+		{name: "service case-insensitive", patterns: []string{"ECS.ListClusters"}, info: listClusters, want: true},
+		{name: "operation case-sensitive", patterns: []string{"ecs.listclusters"}, info: listClusters, want: false},
+		{name: "other service", patterns: []string{"eks.ListClusters"}, info: listClusters, want: false},
+		{name: "other operation", patterns: []string{"ecs.ListTasks"}, info: listClusters, want: false},
+		{name: "operation wildcard", patterns: []string{"ecs.List*"}, info: listClusters, want: true},
+		{name: "service wildcard", patterns: []string{"*.ListClusters"}, info: listClusters, want: true},
+		{name: "output type", patterns: []string{"ListClustersOutput"}, info: listClusters, want: true},
+		{name: "output type with service", patterns: []string{"ecs.ListClustersOutput"}, info: listClusters, want: true},
+		{name: "operation without service", patterns: []string{"ListClusters"}, info: listClusters, want: true},
+		{name: "any of patterns", patterns: []string{"ecs.ListTasks", "ecs.ListClusters"}, info: listClusters, want: true},
+		{name: "no patterns", patterns: nil, info: listClusters, want: false},
+		{name: "v1 WithContext", patterns: []string{"iam.ListUsers"}, info: listUsersV1, want: true},
+		{name: "output type only", patterns: []string{"ecs.ListClusters"}, info: apiCallInfo{serviceName: "ecs", typeName: "ListClustersOutput"}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesOperation(tt.patterns, tt.info); got != tt.want {
+				t.Errorf("matchesOperation(%v) = %v, want %v", tt.patterns, got, tt.want)
 			}
 		})
 	}
 }
+
+// TestValidateOperationPatterns verifies that malformed operation patterns are rejected
+func TestValidateOperationPatterns(t *testing.T) {
+	valid := []string{"ecs.ListClusters", "iam.List*", "ListTasksOutput", "*.List?"}
+	if err := validateOperationPatterns(valid); err != nil {
+		t.Errorf("validateOperationPatterns(%v) error = %v", valid, err)
+	}
+
+	for _, pattern := range []string{"ecs.[", "[.ListClusters", ".ListClusters", "ecs.", "ecs.ListClusters.Output", ""} {
+		if err := validateOperationPatterns([]string{pattern}); err == nil {
+			t.Errorf("validateOperationPatterns(%q): expected error", pattern)
+		}
+	}
+}
+
+// TestMatchesPathPattern verifies matching of glob patterns against file names
+func TestMatchesPathPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{pattern: "internal/mocks/**", name: "/src/app/internal/mocks/client.go", want: true},
+		{pattern: "internal/mocks/**", name: "/src/app/internal/mocks/ecs/client.go", want: true},
+		{pattern: "internal/mocks/**", name: "/src/app/internal/client.go", want: false},
+		{pattern: "internal/mocks/**", name: "/src/app/internal/mocksx/client.go", want: false},
+		{pattern: "**/zz_generated*.go", name: "/src/app/api/zz_generated.deepcopy.go", want: true},
+		{pattern: "**/zz_generated*.go", name: "/src/app/zz_generated.go", want: true},
+		{pattern: "**/zz_generated*.go", name: "/src/app/api/generated.go", want: false},
+		{pattern: "*_mock.go", name: "/src/app/client_mock.go", want: true},
+		{pattern: "*_mock.go", name: "/src/app/client.go", want: false},
+		{pattern: "app/**/mock.go", name: "/src/app/mock.go", want: true},
+		{pattern: "app/**/mock.go", name: "/src/app/a/b/mock.go", want: true},
+		{pattern: "/src/app/*.go", name: "/src/app/client.go", want: true},
+		{pattern: "/app/*.go", name: "/src/app/client.go", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.name, func(t *testing.T) {
+			if got := matchesPathPattern(tt.pattern, tt.name); got != tt.want {
+				t.Errorf("matchesPathPattern(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+			}
+		})
+	}
+
+	for _, pattern := range []string{"", "internal/[mocks"} {
+		if err := validatePathPatterns([]string{pattern}); err == nil {
+			t.Errorf("validatePathPatterns(%q): expected error", pattern)
+		}
+	}
+}
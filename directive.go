@@ -0,0 +1,192 @@
+package awspagination
+
+import (
+	"go/ast"
+	"go/token"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// ignoreDirective is the comment prefix suppressing diagnostics of the analyzer.
+// Unlike //nolint:awspagination, which only golangci-lint understands, it is honored by the
+// analyzer itself and thus works in the standalone binary too.
+const ignoreDirective = "//awspagination:ignore"
+
+// directive is an //awspagination:ignore comment, suppressing the diagnostics reported
+// between the lines from and to of its file (inclusive).
+type directive struct {
+	comment *ast.Comment
+	file    *token.File
+	from    int
+	to      int
+
+	// reason is the reason given after the directive.
+	reason string
+
+	// used is set when the directive suppresses at least one diagnostic.
+	used bool
+}
+
+// ignoreDirectives holds the directives of the files of a package.
+type ignoreDirectives struct {
+	directives []*directive
+}
+
+// parseIgnoreDirectives collects the //awspagination:ignore directives of files, and reports
+// directives without a reason, which suppress nothing. The scope of a directive depends on
+// where it is written:
+//   - File: in a comment before the package clause, the whole file
+//   - Function: in the doc comment of a function declaration, the whole function
+//   - Line: at the end of a line, that line; on a line of its own, the statement or
+//     declaration starting on the next line
+func parseIgnoreDirectives(pass *analysis.Pass, files []*ast.File) *ignoreDirectives {
+	directives := &ignoreDirectives{}
+	for _, file := range files {
+		tokFile := pass.Fset.File(file.Pos())
+		content, err := pass.ReadFile(tokFile.Name())
+		if err != nil {
+			continue
+		}
+
+		funcDocs := make(map[*ast.CommentGroup]*ast.FuncDecl)
+		for _, decl := range file.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Doc != nil {
+				funcDocs[funcDecl.Doc] = funcDecl
+			}
+		}
+
+		for _, group := range file.Comments {
+			for _, comment := range group.List {
+				reason, ok := strings.CutPrefix(comment.Text, ignoreDirective)
+				if !ok || (reason != "" && reason[0] != ' ' && reason[0] != '\t') {
+					continue
+				}
+				if strings.TrimSpace(reason) == "" {
+					pass.Report(analysis.Diagnostic{
+						Pos:      comment.Pos(),
+						Category: ruleInvalidDirective,
+						Message:  ignoreDirective + " directive requires a reason",
+					})
+					continue
+				}
+
+				d := &directive{comment: comment, file: tokFile, reason: strings.TrimSpace(reason)}
+				line := tokFile.Line(comment.Pos())
+				switch {
+				case comment.End() < file.Package:
+					d.from, d.to = 1, tokFile.LineCount()
+				case funcDocs[group] != nil:
+					d.from, d.to = tokFile.Line(funcDocs[group].Pos()), tokFile.Line(funcDocs[group].End())
+				case strings.TrimSpace(string(lineContent(content, tokFile.Offset(comment.Pos())))) == "":
+					d.from, d.to = line+1, nodeEndLine(tokFile, file, line+1)
+				default:
+					d.from, d.to = line, line
+				}
+				directives.directives = append(directives.directives, d)
+			}
+		}
+	}
+	return directives
+}
+
+// suppresses reports whether a directive suppresses a diagnostic at pos, and marks the
+// matching directives as used.
+func (ds *ignoreDirectives) suppresses(fset *token.FileSet, pos token.Pos) bool {
+	file := fset.File(pos)
+	if file == nil {
+		return false
+	}
+	line := file.Line(pos)
+
+	suppressed := false
+	for _, d := range ds.directives {
+		if d.file == file && d.from <= line && line <= d.to {
+			d.used = true
+			suppressed = true
+		}
+	}
+	return suppressed
+}
+
+// reason returns the reason of the first directive suppressing a diagnostic at pos, or
+// empty string if there is none. Unlike suppresses, it does not mark directives as used.
+func (ds *ignoreDirectives) reason(fset *token.FileSet, pos token.Pos) string {
+	file := fset.File(pos)
+	if file == nil {
+		return ""
+	}
+	line := file.Line(pos)
+
+	for _, d := range ds.directives {
+		if d.file == file && d.from <= line && line <= d.to {
+			return d.reason
+		}
+	}
+	return ""
+}
+
+// filter returns a copy of pass whose Report drops the diagnostics suppressed by a directive.
+func (ds *ignoreDirectives) filter(pass *analysis.Pass) *analysis.Pass {
+	filtered := *pass
+	filtered.Report = func(diagnostic analysis.Diagnostic) {
+		if !ds.suppresses(pass.Fset, diagnostic.Pos) {
+			pass.Report(diagnostic)
+		}
+	}
+	return &filtered
+}
+
+// reportUnused reports the directives that did not suppress any diagnostic, since they are
+// likely left over from code that has been fixed since.
+// Directives covering one of the positions gated are not reported, since they may suppress
+// a diagnostic that is only reported with flags that are off (see inventory.flagGated).
+func (ds *ignoreDirectives) reportUnused(pass *analysis.Pass, gated []token.Pos) {
+	for _, d := range ds.directives {
+		if !d.used && !slices.ContainsFunc(gated, func(pos token.Pos) bool { return d.covers(pass.Fset, pos) }) {
+			pass.Report(analysis.Diagnostic{
+				Pos:      d.comment.Pos(),
+				Category: ruleUnusedDirective,
+				Message:  "unused " + ignoreDirective + " directive: no diagnostic is suppressed",
+			})
+		}
+	}
+}
+
+// covers reports whether the directive suppresses diagnostics at pos.
+func (d *directive) covers(fset *token.FileSet, pos token.Pos) bool {
+	if fset.File(pos) != d.file {
+		return false
+	}
+	line := d.file.Line(pos)
+	return d.from <= line && line <= d.to
+}
+
+// lineContent returns the content of the line containing offset, up to offset.
+func lineContent(content []byte, offset int) []byte {
+	start := offset
+	for start > 0 && content[start-1] != '\n' {
+		start--
+	}
+	return content[start:offset]
+}
+
+// nodeEndLine returns the last line of the outermost statement or declaration of file
+// starting on line, or line itself if there is none.
+func nodeEndLine(tokFile *token.File, file *ast.File, line int) int {
+	end := line
+	ast.Inspect(file, func(node ast.Node) bool {
+		if node == nil || tokFile.Line(node.Pos()) > line || tokFile.Line(node.End()) < line {
+			return false
+		}
+		switch node.(type) {
+		case ast.Stmt, ast.Decl, ast.Spec:
+			if tokFile.Line(node.Pos()) == line {
+				end = max(end, tokFile.Line(node.End()))
+			}
+		}
+		return true
+	})
+	return end
+}
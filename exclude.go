@@ -0,0 +1,93 @@
+package awspagination
+
+import (
+	"fmt"
+	"go/ast"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// skippedFiles returns the names of the files of the package that are not analyzed with cfg:
+//   - Test files (*_test.go), unless cfg.IncludeTests is set
+//   - Generated files carrying a "// Code generated ... DO NOT EDIT." header, unless
+//     cfg.IncludeGenerated is set
+//   - Files matching one of cfg.ExcludePaths, see matchesPathPattern
+func skippedFiles(pass *analysis.Pass, cfg *Config) map[string]bool {
+	skipped := make(map[string]bool)
+	for _, file := range pass.Files {
+		name := pass.Fset.File(file.Pos()).Name()
+		switch {
+		case !cfg.IncludeTests && isTestFile(pass, file),
+			!cfg.IncludeGenerated && ast.IsGenerated(file),
+			matchesPathPatterns(cfg.ExcludePaths, name):
+			skipped[name] = true
+		}
+	}
+	return skipped
+}
+
+// matchesPathPatterns reports whether any of patterns matches the file name.
+func matchesPathPatterns(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matchesPathPattern(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPathPattern reports whether the slash-separated glob pattern matches the file name.
+// Each element of the pattern is matched against a path element with path.Match, and
+// "**" matches any number of path elements (e.g., "internal/mocks/**", "**/zz_generated*.go").
+// Absolute patterns match from the root; relative patterns match the trailing elements of
+// the name, so that "internal/mocks/**" excludes the mocks of any module analyzed.
+func matchesPathPattern(pattern, name string) bool {
+	names := strings.Split(filepath.ToSlash(name), "/")
+	patterns := strings.Split(pattern, "/")
+	if path.IsAbs(pattern) {
+		return matchElements(patterns, names)
+	}
+	for i := range names {
+		if matchElements(patterns, names[i:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchElements reports whether the pattern elements match all of the path elements.
+func matchElements(patterns, names []string) bool {
+	if len(patterns) == 0 {
+		return len(names) == 0
+	}
+	if patterns[0] == "**" {
+		for i := 0; i <= len(names); i++ {
+			if matchElements(patterns[1:], names[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(names) == 0 {
+		return false
+	}
+	matched, _ := path.Match(patterns[0], names[0])
+	return matched && matchElements(patterns[1:], names[1:])
+}
+
+// validatePathPatterns reports the first malformed pattern of patterns,
+// see matchesPathPattern.
+func validatePathPatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if pattern == "" {
+			return fmt.Errorf("invalid path pattern: empty pattern")
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid path pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
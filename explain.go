@@ -0,0 +1,146 @@
+package awspagination
+
+import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"io"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// lockedWriter serializes the writes to w, so that the traces of packages analyzed in
+// parallel do not interleave.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (w *lockedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write(p)
+}
+
+// trace is the decision trace of a candidate List API call for -explain, written at
+// once when the decision is made.
+// A nil *trace discards everything, so that callers need not check whether -explain
+// is enabled.
+type trace struct {
+	pass  *analysis.Pass
+	out   io.Writer
+	call  *ast.CallExpr
+	lines []string
+
+	// paginatorPage, awsSDK and tokenFields tell why the call is not checked when
+	// extractPaginationInfo rejects it, see notChecked.
+	paginatorPage bool
+	awsSDK        bool
+	tokenFields   []string
+}
+
+// explainCall starts the trace of callExpr if -explain is enabled and callExpr is a
+// candidate, which is a call whose result has a pagination token field, from the AWS SDK
+// or not. Other calls (e.g., fmt.Sprintf or s3.GetObject) are left out to keep the trace
+// readable.
+// The trace starts with the facts the decision is based on: the result type, whether it
+// comes from the AWS SDK, the service and the pagination token fields.
+func explainCall(pass *analysis.Pass, cfg *Config, callExpr *ast.CallExpr) *trace {
+	if !cfg.Explain {
+		return nil
+	}
+	resultType := extractResultType(pass, callExpr)
+	if resultType == nil {
+		return nil
+	}
+
+	info := extractAPICallInfo(callExpr, resultType)
+	t := &trace{
+		pass:          pass,
+		out:           cfg.explainOutput,
+		call:          callExpr,
+		paginatorPage: isPaginatorMethod(pass, callExpr),
+		awsSDK:        isAWSSDKType(resultType),
+		tokenFields:   paginationTokenFieldsOf(cfg, resultType, info.serviceName),
+	}
+	if len(t.tokenFields) == 0 {
+		return nil
+	}
+
+	qualifier := func(pkg *types.Package) string { return pkg.Name() }
+	t.add("result type: %s (AWS SDK type: %t)", types.TypeString(resultType, qualifier), t.awsSDK)
+	if t.awsSDK {
+		t.add("service: %s, operation: %s", cmp.Or(info.serviceName, "unknown"), cmp.Or(strings.TrimSuffix(info.typeName, "Output"), "unknown"))
+	}
+	t.add("token fields: %s", strings.Join(t.tokenFields, ", "))
+	return t
+}
+
+// add adds a line to the trace.
+func (t *trace) add(format string, args ...any) {
+	if t == nil {
+		return
+	}
+	t.lines = append(t.lines, fmt.Sprintf(format, args...))
+}
+
+// evidence adds the node taken as evidence of the pagination handling to the trace.
+func (t *trace) evidence(handling paginationHandling) {
+	if t == nil {
+		return
+	}
+	if handling.kind == handlingNone {
+		t.add("handling: none found")
+		return
+	}
+	t.add("handling: %s, evidence: %s at %s", handling.kind.status(), nodeString(handling.node), t.pass.Fset.Position(handling.node.Pos()))
+}
+
+// notChecked decides that the call is not checked, because extractPaginationInfo
+// rejected it.
+func (t *trace) notChecked() {
+	switch {
+	case t == nil:
+		return
+	case t.paginatorPage:
+		t.decide("not checked: the call reads a page of a paginator")
+	case !t.awsSDK:
+		t.decide("not checked: the result type does not come from the AWS SDK")
+	default:
+		t.decide("not checked")
+	}
+}
+
+// decide adds the decision to the trace and writes the trace.
+func (t *trace) decide(format string, args ...any) {
+	if t == nil {
+		return
+	}
+	t.add("decision: "+format, args...)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: explain %s\n", t.pass.Fset.Position(t.call.Pos()), nodeString(t.call.Fun))
+	for _, line := range t.lines {
+		b.WriteString("\t" + line + "\n")
+	}
+	io.WriteString(t.out, b.String())
+}
+
+// nodeString returns the source form of an expression, or the type of any other node.
+func nodeString(node ast.Node) string {
+	if expr, ok := node.(ast.Expr); ok {
+		return types.ExprString(expr)
+	}
+	return fmt.Sprintf("%T", node)
+}
+
+// funcName returns the name of fn for traces, see funcDeclName.
+func funcName(fn ast.Node) string {
+	if funcDecl, ok := fn.(*ast.FuncDecl); ok {
+		return funcDeclName(funcDecl)
+	}
+	return "a function literal"
+}
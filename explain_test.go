@@ -0,0 +1,116 @@
+package awspagination
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// TestExplain verifies the decision traces written with -explain
+func TestExplain(t *testing.T) {
+	var buf bytes.Buffer
+	analyzer := NewAnalyzer(Config{
+		Explain:           true,
+		ExcludeOperations: []string{"ecs.ListAccountSettings"},
+		explainOutput:     &buf,
+	})
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzer, "test/explain")
+
+	// Traces by the line of the traced call. Each trace starts with a header line
+	// (<file>:<line>:<column>: explain <call>), followed by indented lines
+	traces := make(map[string]string)
+	var line string
+	for _, text := range strings.SplitAfter(buf.String(), "\n") {
+		if text == "" {
+			continue
+		}
+		if !strings.HasPrefix(text, "\t") {
+			position, _, ok := strings.Cut(text, ": explain ")
+			parts := strings.Split(position, ":")
+			if !ok || len(parts) < 3 {
+				t.Fatalf("malformed trace header %q", text)
+			}
+			line = parts[len(parts)-2]
+		}
+		traces[line] += text
+	}
+
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{line: "19", want: []string{
+			"explain client.ListTasks",
+			"result type: *ecs.ListTasksOutput (AWS SDK type: true)",
+			"service: ecs, operation: ListTasks",
+			"token fields: NextToken",
+			"variable: out, declared in paginatorOfSameClient",
+			"handling: paginator, evidence: ecs.NewListTasksPaginator(client, &ecs.ListTasksInput{}) at ",
+			"explain.go:22:15",
+			"decision: not reported: pagination is handled",
+		}},
+		{line: "24", want: []string{
+			"explain paginator.NextPage",
+			"decision: not checked: the call reads a page of a paginator",
+		}},
+		{line: "34", want: []string{
+			"handling: manual-loop, evidence: services.NextToken at ",
+			"decision: not reported: pagination is handled",
+		}},
+		{line: "40", want: []string{
+			"handling: none found",
+			"decision: reported: no pagination handling found",
+		}},
+		{line: "46", want: []string{
+			"result type: *explain.page (AWS SDK type: false)",
+			"token fields: NextToken",
+			"decision: not checked: the result type does not come from the AWS SDK",
+		}},
+		{line: "56", want: []string{
+			"decision: not reported here: the result is returned, so the callers of returned are checked instead",
+		}},
+		{line: "62", want: []string{
+			"explain client.ListServices",
+			"decision: not reported here: the result is returned, so the callers of returnedDirectly are checked instead",
+		}},
+		{line: "67", want: []string{
+			"decision: not reported: the result is passed to handle, which handles its pagination",
+		}},
+		{line: "78", want: []string{
+			"decision: reported: the result is passed to log.Println, which does not handle its pagination",
+		}},
+		{line: "83", want: []string{
+			"explain firstTaskDefinitions",
+			"decision: reported: the result is consumed in place, so pagination cannot be handled",
+		}},
+		{line: "87", want: []string{
+			"decision: not reported here: the result is returned, so the callers of firstTaskDefinitions are checked instead",
+		}},
+		{line: "93", want: []string{
+			"decision: not reported: the result is discarded",
+		}},
+		{line: "98", want: []string{
+			"decision: not reported: the operation is excluded",
+		}},
+	}
+
+	for _, tt := range tests {
+		trace, ok := traces[tt.line]
+		if !ok {
+			t.Errorf("no trace of the call on line %s in:\n%s", tt.line, buf.String())
+			continue
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(trace, want) {
+				t.Errorf("trace of the call on line %s does not contain %q:\n%s", tt.line, want, trace)
+			}
+		}
+	}
+	if len(traces) != len(tests) {
+		t.Errorf("got %d traces, want %d:\n%s", len(traces), len(tests), buf.String())
+	}
+}
@@ -0,0 +1,323 @@
+package awspagination
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// paginationHandlerFact is exported for functions that handle pagination of
+// AWS SDK outputs received as parameters.
+// A parameter is considered handled when the function reads one of its pagination
+// token fields, or passes it on to another function that handles it.
+//
+// Facts are serialized along with the package, so a call site in a dependent package
+// can treat passing a List API result to such a helper as pagination handling.
+type paginationHandlerFact struct {
+	// Params holds the indices of the handled parameters (receiver excluded).
+	Params []int
+}
+
+func (*paginationHandlerFact) AFact() {}
+
+func (f *paginationHandlerFact) String() string {
+	return fmt.Sprintf("handlesPagination%v", f.Params)
+}
+
+// handles reports whether the parameter at index i is handled by the function.
+func (f *paginationHandlerFact) handles(i int) bool {
+	return slices.Contains(f.Params, i)
+}
+
+// exportPaginationHandlerFacts exports a paginationHandlerFact for every function
+// declaration that handles pagination for at least one of its parameters.
+// Helpers may delegate to other helpers declared later in the same package,
+// so the declarations are re-examined until no new facts are found.
+func exportPaginationHandlerFacts(pass *analysis.Pass, cfg *Config, funcDecls []*ast.FuncDecl) {
+	for changed := true; changed; {
+		changed = false
+		for _, funcDecl := range funcDecls {
+			fn, ok := pass.TypesInfo.Defs[funcDecl.Name].(*types.Func)
+			if !ok || funcDecl.Body == nil {
+				continue
+			}
+
+			params := handledParams(pass, cfg, funcDecl)
+			if len(params) == 0 {
+				continue
+			}
+
+			// Handled parameters only grow as more facts become available,
+			// so comparing the lengths is enough to detect a change
+			var existing paginationHandlerFact
+			if pass.ImportObjectFact(fn, &existing) && len(existing.Params) == len(params) {
+				continue
+			}
+
+			pass.ExportObjectFact(fn, &paginationHandlerFact{Params: params})
+			changed = true
+		}
+	}
+}
+
+// handledParams returns the indices of the parameters of funcDecl that are AWS SDK
+// outputs with pagination token fields and whose pagination is handled in the body.
+func handledParams(pass *analysis.Pass, cfg *Config, funcDecl *ast.FuncDecl) []int {
+	var params []int
+
+	index := 0
+	for _, field := range funcDecl.Type.Params.List {
+		// Unnamed parameters cannot be accessed, but still occupy an index
+		if len(field.Names) == 0 {
+			index++
+			continue
+		}
+
+		for _, name := range field.Names {
+			if isHandledParam(pass, cfg, funcDecl.Body, name) {
+				params = append(params, index)
+			}
+			index++
+		}
+	}
+
+	return params
+}
+
+// isHandledParam reports whether the parameter declared by name is an AWS SDK output
+// with pagination token fields whose pagination is handled in body.
+func isHandledParam(pass *analysis.Pass, cfg *Config, body *ast.BlockStmt, name *ast.Ident) bool {
+	paramType := pass.TypesInfo.TypeOf(name)
+	if paramType == nil || name.Name == "_" {
+		return false
+	}
+
+	if !isAWSSDKType(paramType) {
+		return false
+	}
+
+	tokenFields := paginationTokenFieldsOf(cfg, paramType, serviceNameOfType(paramType))
+	if len(tokenFields) == 0 {
+		return false
+	}
+
+	obj := pass.TypesInfo.Defs[name]
+	if obj == nil {
+		return false
+	}
+	return hasPaginationHandling(pass, cfg, body, obj, nil, name.Pos(), tokenFields)
+}
+
+// paginationHandlerFactOf returns the paginationHandlerFact of the function called by
+// callExpr, or nil if the callee is unknown (e.g., an interface method or a function value)
+// or does not handle pagination for any of its parameters.
+func paginationHandlerFactOf(pass *analysis.Pass, callExpr *ast.CallExpr) *paginationHandlerFact {
+	callee := typeutil.StaticCallee(pass.TypesInfo, callExpr)
+	if callee == nil {
+		return nil
+	}
+
+	var fact paginationHandlerFact
+	if !pass.ImportObjectFact(callee, &fact) {
+		return nil
+	}
+	return &fact
+}
+
+// paginationObligationFact is exported for functions that return the result of an
+// AWS SDK List API call without handling its pagination.
+// The obligation to handle pagination moves to the callers of such functions,
+// and their diagnostics point back at the originating call.
+type paginationObligationFact struct {
+	// Results holds the indices of the results carrying the unpaginated output.
+	Results []int
+
+	// Method is the originating API method (e.g., "ListObjectsV2").
+	Method string
+
+	// File, Line and Column locate the originating API call.
+	// A position is used instead of token.Pos because facts are serialized
+	// and token.Pos is only meaningful within a single token.FileSet.
+	File   string
+	Line   int
+	Column int
+}
+
+func (*paginationObligationFact) AFact() {}
+
+func (f *paginationObligationFact) String() string {
+	return fmt.Sprintf("paginationObligation%v", f.Results)
+}
+
+// originPos resolves the position of the originating API call in the file set of pass.
+// Falls back to the position of the called function when the originating file is not
+// part of the file set (e.g., when the dependency was loaded from export data).
+func (f *paginationObligationFact) originPos(pass *analysis.Pass, callExpr *ast.CallExpr) token.Pos {
+	pos := token.NoPos
+	pass.Fset.Iterate(func(file *token.File) bool {
+		if file.Name() != f.File || f.Line < 1 || f.Line > file.LineCount() {
+			return true
+		}
+		pos = file.LineStart(f.Line) + token.Pos(f.Column-1)
+		return false
+	})
+	if pos.IsValid() {
+		return pos
+	}
+
+	if callee := typeutil.StaticCallee(pass.TypesInfo, callExpr); callee != nil {
+		return callee.Pos()
+	}
+	return callExpr.Pos()
+}
+
+// paginationObligationFactOf returns the paginationObligationFact of the function called
+// by callExpr, or nil if the callee is unknown or does not return an unpaginated result.
+func paginationObligationFactOf(pass *analysis.Pass, callExpr *ast.CallExpr) *paginationObligationFact {
+	callee := typeutil.StaticCallee(pass.TypesInfo, callExpr)
+	if callee == nil {
+		return nil
+	}
+
+	var fact paginationObligationFact
+	if !pass.ImportObjectFact(callee, &fact) {
+		return nil
+	}
+	return &fact
+}
+
+// transferPaginationObligations exports a paginationObligationFact for every function
+// that returns an unhandled List API result, and returns the calls that remain to be
+// reported in the function where they are made.
+func transferPaginationObligations(pass *analysis.Pass, calls []unhandledCall) []unhandledCall {
+	// Group the returned calls by the function returning them
+	returned := make(map[*types.Func][]unhandledCall)
+	var remaining []unhandledCall
+	for _, call := range calls {
+		if call.funcDecl == nil || len(call.results) == 0 {
+			remaining = append(remaining, call)
+			continue
+		}
+		fn, ok := pass.TypesInfo.Defs[call.funcDecl.Name].(*types.Func)
+		if !ok {
+			remaining = append(remaining, call)
+			continue
+		}
+		returned[fn] = append(returned[fn], call)
+	}
+
+	// Wrappers may return the results of other wrappers declared later in the same package,
+	// so the facts are resolved recursively to always carry the originating SDK call
+	obligations := make(map[*types.Func]*paginationObligationFact)
+	var resolve func(fn *types.Func) *paginationObligationFact
+	resolve = func(fn *types.Func) *paginationObligationFact {
+		if fact, ok := obligations[fn]; ok || len(returned[fn]) == 0 {
+			return fact
+		}
+		obligations[fn] = nil // guard against recursive wrappers
+
+		var fact *paginationObligationFact
+		for _, call := range returned[fn] {
+			if fact == nil {
+				fact = newPaginationObligationFact(pass, call, resolve)
+			}
+			for _, result := range call.results {
+				if !slices.Contains(fact.Results, result) {
+					fact.Results = append(fact.Results, result)
+				}
+			}
+		}
+
+		obligations[fn] = fact
+		pass.ExportObjectFact(fn, fact)
+		return fact
+	}
+	for fn := range returned {
+		resolve(fn)
+	}
+
+	return remaining
+}
+
+// newPaginationObligationFact creates the obligation fact for a function returning the
+// result of call. If call itself is made to a function with an obligation, the original
+// API call is carried over so that diagnostics always point at the SDK call.
+// Obligations of functions in the current package are obtained through resolve.
+func newPaginationObligationFact(pass *analysis.Pass, call unhandledCall, resolve func(*types.Func) *paginationObligationFact) *paginationObligationFact {
+	origin := paginationObligationFactOf(pass, call.callExpr)
+	if callee := typeutil.StaticCallee(pass.TypesInfo, call.callExpr); callee != nil && callee.Pkg() == pass.Pkg {
+		origin = resolve(callee)
+	}
+	if origin != nil {
+		return &paginationObligationFact{
+			Method: origin.Method,
+			File:   origin.File,
+			Line:   origin.Line,
+			Column: origin.Column,
+		}
+	}
+
+	position := pass.Fset.Position(call.callExpr.Pos())
+	return &paginationObligationFact{
+		Method: call.apiInfo.methodName,
+		File:   position.Filename,
+		Line:   position.Line,
+		Column: position.Column,
+	}
+}
+
+// returnedResults returns the indices of the results of fn, a *ast.FuncDecl or
+// *ast.FuncLit, through which the variable obj is returned, either explicitly or
+// as a named result.
+// Return statements of nested function literals are ignored.
+func returnedResults(pass *analysis.Pass, fn ast.Node, obj types.Object) []int {
+	funcType, body := funcTypeAndBody(fn)
+	if body == nil {
+		return nil
+	}
+
+	var results []int
+
+	// Named results are returned by bare return statements
+	namedResults := make(map[types.Object]int)
+	if funcType.Results != nil {
+		index := 0
+		for _, field := range funcType.Results.List {
+			if len(field.Names) == 0 {
+				index++
+				continue
+			}
+			for _, name := range field.Names {
+				namedResults[pass.TypesInfo.Defs[name]] = index
+				index++
+			}
+		}
+	}
+
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(node.Results) == 0 {
+				if index, ok := namedResults[obj]; ok && !slices.Contains(results, index) {
+					results = append(results, index)
+				}
+				return true
+			}
+			for i, result := range node.Results {
+				if ident, ok := result.(*ast.Ident); ok && pass.TypesInfo.Uses[ident] == obj && !slices.Contains(results, i) {
+					results = append(results, i)
+				}
+			}
+		}
+		return true
+	})
+
+	return results
+}
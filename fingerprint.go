@@ -0,0 +1,136 @@
+package awspagination
+
+import (
+	"go/ast"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// Result is the result of the analyzer for a package, available to drivers through
+// the results of the analysis (e.g., checker.Action.Result).
+type Result struct {
+	// Findings are the diagnostics reported for the package, in report order.
+	// Diagnostics about //awspagination:ignore directives are not included.
+	Findings []Finding
+
+	// CallSites are the AWS SDK calls of the package whose output is paginated, in order
+	// of position, along with how their pagination is handled (e.g., for audits).
+	CallSites []CallSite
+}
+
+// Finding is a diagnostic reported by the analyzer along with its fingerprint.
+type Finding struct {
+	Diagnostic  analysis.Diagnostic
+	Fingerprint Fingerprint
+
+	// TokenFields are the pagination token fields of the result of the List API call of
+	// the finding (e.g., ["NextToken"]). Empty if the finding is not about an AWS SDK call.
+	TokenFields []string
+}
+
+// Fingerprint identifies a finding by what it is about rather than where it is, so that
+// it stays the same when unrelated code is added or removed around it (e.g., in baselines).
+// Findings of identical code in the same function share a fingerprint.
+type Fingerprint struct {
+	// Package is the path of the package of the finding.
+	Package string `json:"package"`
+
+	// Function is the name of the function declaration enclosing the finding, qualified
+	// by its receiver type for methods (e.g., "listTasks", "Lister.ListAll").
+	// Empty at package level.
+	Function string `json:"function,omitempty"`
+
+	// Service and Operation are the AWS service and operation of the List API call of
+	// the finding (e.g., "ecs", "ListTasks"). Empty if the finding is not about an AWS SDK call.
+	Service   string `json:"service,omitempty"`
+	Operation string `json:"operation,omitempty"`
+
+	// Variable is the name of the variable the result of the call is assigned to.
+	// Empty if the result is not assigned.
+	Variable string `json:"variable,omitempty"`
+
+	// Message is the first line of the diagnostic message, which tells the kind of finding.
+	Message string `json:"message"`
+}
+
+// recordFindings returns a copy of pass whose Report records every diagnostic as a
+// finding in result before reporting it.
+func recordFindings(pass *analysis.Pass, cfg *Config, result *Result) *analysis.Pass {
+	recording := *pass
+	recording.Report = func(diagnostic analysis.Diagnostic) {
+		result.Findings = append(result.Findings, findingOf(pass, cfg, diagnostic))
+		pass.Report(diagnostic)
+	}
+	return &recording
+}
+
+// findingOf returns the finding of a diagnostic, whose fingerprint and token fields are
+// derived from the innermost call and assignment enclosing its position.
+func findingOf(pass *analysis.Pass, cfg *Config, diagnostic analysis.Diagnostic) Finding {
+	message, _, _ := strings.Cut(diagnostic.Message, "\n")
+	finding := Finding{
+		Diagnostic:  diagnostic,
+		Fingerprint: Fingerprint{Package: pass.Pkg.Path(), Message: message},
+	}
+	fingerprint := &finding.Fingerprint
+
+	file := fileOf(pass, diagnostic.Pos)
+	if file == nil {
+		return finding
+	}
+	path, _ := astutil.PathEnclosingInterval(file, diagnostic.Pos, diagnostic.Pos)
+
+	var callExpr *ast.CallExpr
+	for _, node := range path {
+		switch node := node.(type) {
+		case *ast.CallExpr:
+			if callExpr == nil {
+				callExpr = node
+			}
+		case *ast.AssignStmt:
+			if fingerprint.Variable == "" {
+				fingerprint.Variable = extractVariableName(node.Lhs[0])
+			}
+		case *ast.ValueSpec:
+			if fingerprint.Variable == "" {
+				fingerprint.Variable = node.Names[0].Name
+			}
+		case *ast.FuncDecl:
+			fingerprint.Function = funcDeclName(node)
+		}
+	}
+
+	if callExpr != nil {
+		if resultType := extractResultType(pass, callExpr); resultType != nil && isAWSSDKType(resultType) {
+			info := extractAPICallInfo(callExpr, resultType)
+			fingerprint.Service = info.serviceName
+			fingerprint.Operation = strings.TrimSuffix(info.typeName, "Output")
+			finding.TokenFields = paginationTokenFieldsOf(cfg, resultType, info.serviceName)
+		}
+	}
+	return finding
+}
+
+// funcDeclName returns the name of the function declaration, qualified by its receiver
+// type for methods (e.g., "Lister.ListAll" for func (l *Lister) ListAll()).
+func funcDeclName(funcDecl *ast.FuncDecl) string {
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+		return funcDecl.Name.Name
+	}
+	recv := funcDecl.Recv.List[0].Type
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv = star.X
+	}
+	switch generic := recv.(type) {
+	case *ast.IndexExpr:
+		recv = generic.X
+	case *ast.IndexListExpr:
+		recv = generic.X
+	}
+	if ident, ok := recv.(*ast.Ident); ok {
+		return ident.Name + "." + funcDecl.Name.Name
+	}
+	return funcDecl.Name.Name
+}
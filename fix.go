@@ -0,0 +1,398 @@
+package awspagination
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// paginatorLoopFix returns a suggested fix rewriting a List API call into a loop over
+// the paginator of its operation, or nil if the call does not match the supported shape:
+//
+//	out, err := client.ListTasks(ctx, in)
+//	if err != nil {
+//		return err
+//	}
+//	use(out.TaskArns)
+//
+// is rewritten into
+//
+//	var taskArns []string
+//	paginator := ecs.NewListTasksPaginator(client, in)
+//	for paginator.HasMorePages() {
+//		page, err := paginator.NextPage(ctx)
+//		if err != nil {
+//			return err
+//		}
+//		taskArns = append(taskArns, page.TaskArns...)
+//	}
+//	use(taskArns)
+//
+// The fix is only offered when the rewrite is known to preserve the surrounding code:
+// the error check must directly follow the call and end with a return statement, and the
+// result must only be used to read slice fields after it. stack is the stack of the
+// assignment as provided by inspector.WithStack, and fn is the function declaring the result.
+func paginatorLoopFix(pass *analysis.Pass, assign *ast.AssignStmt, stack []ast.Node, fn ast.Node) *analysis.SuggestedFix {
+	if assign.Tok != token.DEFINE || len(assign.Lhs) != 2 || len(assign.Rhs) != 1 || fn == nil {
+		return nil
+	}
+	callExpr, ok := assign.Rhs[0].(*ast.CallExpr)
+	if !ok || len(callExpr.Args) != 2 {
+		return nil
+	}
+	sel, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	resultIdent, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	errIdent, ok := assign.Lhs[1].(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	resultObj, errObj := pass.TypesInfo.Defs[resultIdent], pass.TypesInfo.Defs[errIdent]
+	if resultObj == nil || errObj == nil {
+		return nil
+	}
+
+	// Only direct calls of an AWS SDK v2 client method have a paginator to rewrite into
+	method, ok := typeutil.Callee(pass.TypesInfo, callExpr).(*types.Func)
+	if !ok || method.Pkg() == nil || isAWSSDKv1Package(method.Pkg().Path()) {
+		return nil
+	}
+	if paginator, known := operationPaginator(resultObj.Type()); paginator == nil || !known || paginator.Pkg() != method.Pkg() {
+		return nil
+	}
+	constructor := "New" + method.Name() + "Paginator"
+	if method.Pkg().Scope().Lookup(constructor) == nil {
+		return nil
+	}
+
+	ifStmt := followingErrorCheck(pass, stack, assign, errObj)
+	if ifStmt == nil {
+		return nil
+	}
+
+	_, body := funcTypeAndBody(fn)
+	fields, ok := fieldReads(pass, body, resultObj, errObj, ifStmt)
+	if !ok || len(fields) == 0 {
+		return nil
+	}
+
+	file, ok := stack[0].(*ast.File)
+	if !ok {
+		return nil
+	}
+	qualifier, qualified := fileQualifier(pass, file, assign.Pos())
+	pkgName := qualifier(method.Pkg())
+
+	// Accumulators are named after the fields they collect (TaskArns -> taskArns)
+	names := []string{"paginator", "page"}
+	accumulators := make(map[string]string)
+	var decls []string
+	for _, field := range fields {
+		name := accumulatorName(field.name)
+		accumulators[field.name] = name
+		names = append(names, name)
+		decls = append(decls, "var "+name+" "+types.TypeString(field.typ, qualifier))
+	}
+	if !*qualified || pkgName == "" {
+		return nil
+	}
+	for _, name := range names {
+		if !freeName(pass, name, fn, assign.Pos()) {
+			return nil
+		}
+	}
+
+	content, err := pass.ReadFile(pass.Fset.File(assign.Pos()).Name())
+	if err != nil {
+		return nil
+	}
+	source := func(node ast.Node) string {
+		return string(content[pass.Fset.Position(node.Pos()).Offset:pass.Fset.Position(node.End()).Offset])
+	}
+	indent := lineIndent(content, pass.Fset.Position(assign.Pos()).Offset)
+
+	var loop strings.Builder
+	for _, decl := range decls {
+		loop.WriteString(decl + "\n" + indent)
+	}
+	loop.WriteString("paginator := " + pkgName + "." + constructor + "(" + source(sel.X) + ", " + source(callExpr.Args[1]) + ")\n")
+	loop.WriteString(indent + "for paginator.HasMorePages() {\n")
+	loop.WriteString(indent + "\tpage, " + errIdent.Name + " := paginator.NextPage(" + source(callExpr.Args[0]) + ")\n")
+	loop.WriteString(indent + "\t" + reindent(pass, content, ifStmt) + "\n")
+	for _, field := range fields {
+		name := accumulators[field.name]
+		loop.WriteString(indent + "\t" + name + " = append(" + name + ", page." + field.name + "...)\n")
+	}
+	loop.WriteString(indent + "}")
+
+	edits := []analysis.TextEdit{{
+		Pos:     assign.Pos(),
+		End:     ifStmt.End(),
+		NewText: []byte(loop.String()),
+	}}
+	for _, field := range fields {
+		for _, read := range field.reads {
+			edits = append(edits, analysis.TextEdit{
+				Pos:     read.Pos(),
+				End:     read.End(),
+				NewText: []byte(accumulators[field.name]),
+			})
+		}
+	}
+
+	return &analysis.SuggestedFix{
+		Message:   "Iterate all pages with " + pkgName + "." + constructor,
+		TextEdits: edits,
+	}
+}
+
+// followingErrorCheck returns the `if err != nil { ... }` statement directly following
+// assign in its block, or nil if there is none.
+// The statement must not have an init or else branch, and its body must end with a return
+// statement and contain no branch statements, so that it can be moved into a loop as is.
+func followingErrorCheck(pass *analysis.Pass, stack []ast.Node, assign *ast.AssignStmt, errObj types.Object) *ast.IfStmt {
+	var stmts []ast.Stmt
+	switch parent := stack[len(stack)-2].(type) {
+	case *ast.BlockStmt:
+		stmts = parent.List
+	case *ast.CaseClause:
+		stmts = parent.Body
+	case *ast.CommClause:
+		stmts = parent.Body
+	}
+	i := slices.Index(stmts, ast.Stmt(assign))
+	if i < 0 || i+1 >= len(stmts) {
+		return nil
+	}
+
+	ifStmt, ok := stmts[i+1].(*ast.IfStmt)
+	if !ok || ifStmt.Init != nil || ifStmt.Else != nil || len(ifStmt.Body.List) == 0 {
+		return nil
+	}
+	cond, ok := ifStmt.Cond.(*ast.BinaryExpr)
+	if !ok || cond.Op != token.NEQ {
+		return nil
+	}
+	x, ok := cond.X.(*ast.Ident)
+	if !ok || pass.TypesInfo.Uses[x] != errObj {
+		return nil
+	}
+	if y, ok := cond.Y.(*ast.Ident); !ok || y.Name != "nil" {
+		return nil
+	}
+	if _, ok := ifStmt.Body.List[len(ifStmt.Body.List)-1].(*ast.ReturnStmt); !ok {
+		return nil
+	}
+
+	branches := false
+	ast.Inspect(ifStmt.Body, func(node ast.Node) bool {
+		if _, ok := node.(*ast.BranchStmt); ok {
+			branches = true
+		}
+		return !branches
+	})
+	if branches {
+		return nil
+	}
+	return ifStmt
+}
+
+// fieldRead is a field of a List API result read after the call.
+type fieldRead struct {
+	name  string
+	typ   types.Type
+	reads []*ast.SelectorExpr
+}
+
+// fieldReads returns the fields of the result read in body after ifStmt, in order of first
+// read. ok is false if the result is used otherwise (e.g., passed on as a whole, or read
+// before the error check), if a non-slice field is read, or if err is used after ifStmt.
+func fieldReads(pass *analysis.Pass, body ast.Node, resultObj, errObj types.Object, ifStmt *ast.IfStmt) ([]*fieldRead, bool) {
+	selectors := make(map[*ast.Ident]*ast.SelectorExpr)
+	ast.Inspect(body, func(node ast.Node) bool {
+		if sel, ok := node.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok {
+				selectors[ident] = sel
+			}
+		}
+		return true
+	})
+
+	var fields []*fieldRead
+	ok := true
+	ast.Inspect(body, func(node ast.Node) bool {
+		ident, isIdent := node.(*ast.Ident)
+		if !isIdent || !ok {
+			return ok
+		}
+		switch pass.TypesInfo.Uses[ident] {
+		case errObj:
+			if ident.Pos() < ifStmt.Pos() || ident.Pos() >= ifStmt.End() {
+				ok = false
+			}
+		case resultObj:
+			sel := selectors[ident]
+			if sel == nil || ident.Pos() < ifStmt.End() {
+				ok = false
+				return false
+			}
+			selection := pass.TypesInfo.Selections[sel]
+			if selection == nil || selection.Kind() != types.FieldVal {
+				ok = false
+				return false
+			}
+			if _, isSlice := selection.Type().Underlying().(*types.Slice); !isSlice {
+				ok = false
+				return false
+			}
+			i := slices.IndexFunc(fields, func(f *fieldRead) bool { return f.name == sel.Sel.Name })
+			if i < 0 {
+				fields = append(fields, &fieldRead{name: sel.Sel.Name, typ: selection.Type()})
+				i = len(fields) - 1
+			}
+			fields[i].reads = append(fields[i].reads, sel)
+		}
+		return true
+	})
+	return fields, ok
+}
+
+// fileQualifier returns a types.Qualifier naming packages as they are imported in file.
+// The returned flag is cleared when a package is not imported under a usable name
+// at pos (e.g., not imported at all, dot-imported, or shadowed).
+func fileQualifier(pass *analysis.Pass, file *ast.File, pos token.Pos) (types.Qualifier, *bool) {
+	qualified := true
+	scope := pass.Pkg.Scope().Innermost(pos)
+	qualifier := func(pkg *types.Package) string {
+		if pkg == pass.Pkg {
+			return ""
+		}
+		// Imports are matched by package rather than by path, since vendored
+		// packages may have a path other than their import path
+		for _, spec := range file.Imports {
+			pkgName := pass.TypesInfo.PkgNameOf(spec)
+			if pkgName == nil || pkgName.Imported() != pkg {
+				continue
+			}
+			name := pkgName.Name()
+			if name == "_" || name == "." {
+				break
+			}
+			if scope != nil {
+				if _, obj := scope.LookupParent(name, pos); obj == pkgName {
+					return name
+				}
+			}
+			break
+		}
+		qualified = false
+		return pkg.Name()
+	}
+	return qualifier, &qualified
+}
+
+// freeName reports whether name can be declared at pos without shadowing or conflicting
+// with another declaration. Since the rewrite moves code into a loop and replaces reads
+// further down the function fn, name must not be declared anywhere in fn either.
+func freeName(pass *analysis.Pass, name string, fn ast.Node, pos token.Pos) bool {
+	if token.IsKeyword(name) || !token.IsIdentifier(name) {
+		return false
+	}
+	scope := pass.Pkg.Scope().Innermost(pos)
+	if scope == nil {
+		return false
+	}
+	if _, obj := scope.LookupParent(name, pos); obj != nil {
+		return false
+	}
+	funcType, _ := funcTypeAndBody(fn)
+	return !declaresName(pass.TypesInfo.Scopes[funcType], name)
+}
+
+// declaresName reports whether name is declared in scope or any of its children.
+func declaresName(scope *types.Scope, name string) bool {
+	if scope == nil {
+		return false
+	}
+	if scope.Lookup(name) != nil {
+		return true
+	}
+	for child := range scope.Children() {
+		if declaresName(child, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// reindent returns the source of node in content with one more level of indentation.
+// Lines starting inside a multi-line raw string literal are kept as is, since
+// indenting them would change the value of the literal.
+func reindent(pass *analysis.Pass, content []byte, node ast.Node) string {
+	offset := func(pos token.Pos) int { return pass.Fset.Position(pos).Offset }
+	var literals []*ast.BasicLit
+	ast.Inspect(node, func(n ast.Node) bool {
+		if lit, ok := n.(*ast.BasicLit); ok && lit.Kind == token.STRING && strings.Contains(lit.Value, "\n") {
+			literals = append(literals, lit)
+		}
+		return true
+	})
+
+	var b strings.Builder
+	start := offset(node.Pos())
+	for i, c := range content[start:offset(node.End())] {
+		b.WriteByte(c)
+		if c != '\n' {
+			continue
+		}
+		inLiteral := slices.ContainsFunc(literals, func(lit *ast.BasicLit) bool {
+			return offset(lit.Pos()) <= start+i && start+i < offset(lit.End())
+		})
+		if !inLiteral {
+			b.WriteByte('\t')
+		}
+	}
+	return b.String()
+}
+
+// accumulatorName returns the variable name collecting the items of field across pages
+// (e.g., TaskArns -> taskArns, DBInstances -> dbInstances).
+func accumulatorName(field string) string {
+	runes := []rune(field)
+	n := 0
+	for n < len(runes) && unicode.IsUpper(runes[n]) {
+		n++
+	}
+	// Keep the first letter of the next word (DBInstances -> dbInstances)
+	if n > 1 && n < len(runes) {
+		n--
+	}
+	for i := range n {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+// lineIndent returns the leading whitespace of the line containing offset in content.
+func lineIndent(content []byte, offset int) string {
+	start := offset
+	for start > 0 && content[start-1] != '\n' {
+		start--
+	}
+	end := start
+	for end < offset && (content[end] == ' ' || content[end] == '\t') {
+		end++
+	}
+	return string(content[start:end])
+}
@@ -0,0 +1,205 @@
+package awspagination
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// CallStatus tells how the pagination of a call site is handled.
+type CallStatus string
+
+const (
+	// StatusPaginator is a paginator (or an AWS SDK v1 <Operation>Pages method) iterating
+	// all pages, or a result whose operation is paginated by one.
+	StatusPaginator CallStatus = "paginator"
+
+	// StatusManualLoop is a result whose pagination token is read, as in a manual loop.
+	StatusManualLoop CallStatus = "manual-loop"
+
+	// StatusHelper is a result passed to a function that handles its pagination.
+	StatusHelper CallStatus = "helper"
+
+	// StatusReturned is a result returned to the callers, which handle its pagination.
+	StatusReturned CallStatus = "returned"
+
+	// StatusLimited is a call intentionally limited to a single page (-limited-calls).
+	StatusLimited CallStatus = "limited"
+
+	// StatusExcluded is a call of an operation excluded from the checks (-exclude-operations).
+	StatusExcluded CallStatus = "excluded"
+
+	// StatusDiscarded is a call whose result is not used.
+	StatusDiscarded CallStatus = "discarded"
+
+	// StatusUnchecked is a result assigned to a variable that the type checker cannot
+	// resolve, whose pagination is not checked.
+	StatusUnchecked CallStatus = "unchecked"
+
+	// StatusSuppressed is a call whose finding is suppressed by an ignore directive.
+	StatusSuppressed CallStatus = "suppressed"
+
+	// StatusUnhandled is a call reported by the analyzer.
+	StatusUnhandled CallStatus = "unhandled"
+)
+
+// CallSite is an AWS SDK call of a package whose output is paginated, along with how
+// its pagination is handled.
+type CallSite struct {
+	// Pos is the position of the call.
+	Pos token.Pos
+
+	// Package, Function, Service, Operation and Variable are set as in Fingerprint.
+	Package   string
+	Function  string
+	Service   string
+	Operation string
+	Variable  string
+
+	// Method is the name of the called function or method (e.g., "ListTasks",
+	// "NewListTasksPaginator" or "ListTasksPages").
+	Method string
+
+	// TokenFields are the pagination token fields of the output of the operation.
+	TokenFields []string
+
+	// Status tells how the pagination of the call is handled.
+	Status CallStatus
+
+	// Rule is the ID of the rule of the finding for StatusUnhandled and StatusSuppressed.
+	Rule string
+
+	// Reason is the reason given by the ignore directive for StatusSuppressed.
+	Reason string
+}
+
+// inventory collects the call sites of a package while the checks run. The diagnostics
+// reported while a call site is checked (see check) decide whether it is unhandled or
+// suppressed.
+type inventory struct {
+	cfg        *Config
+	directives *ignoreDirectives
+	sites      []*CallSite
+	calls      map[*ast.CallExpr]*CallSite
+
+	// current is the call site being checked, if any.
+	current *CallSite
+}
+
+func newInventory(cfg *Config, directives *ignoreDirectives) *inventory {
+	return &inventory{cfg: cfg, directives: directives, calls: make(map[*ast.CallExpr]*CallSite)}
+}
+
+// record adds the call site of the List API call callExpr, whose result is assigned to
+// variable (empty if not assigned), with its initial status.
+func (inv *inventory) record(pass *analysis.Pass, callExpr *ast.CallExpr, info apiCallInfo, tokenFields []string, variable string, status CallStatus) *CallSite {
+	site := &CallSite{
+		Pos:         callExpr.Pos(),
+		Package:     pass.Pkg.Path(),
+		Function:    enclosingFuncName(pass, callExpr.Pos()),
+		Service:     info.serviceName,
+		Operation:   strings.TrimSuffix(info.typeName, "Output"),
+		Variable:    variable,
+		Method:      info.methodName,
+		TokenFields: tokenFields,
+		Status:      status,
+	}
+	inv.sites = append(inv.sites, site)
+	inv.calls[callExpr] = site
+	return site
+}
+
+// recordIteration adds the call site of callExpr, which constructs a paginator or calls
+// an <Operation>Pages method of the operation of the service package pkg.
+func (inv *inventory) recordIteration(pass *analysis.Pass, callExpr *ast.CallExpr, pkg *types.Package, operation, variable string) *CallSite {
+	info := apiCallInfo{serviceName: extractServiceNameFromPackage(pkg.Path()), typeName: operation + "Output"}
+	if fn, ok := typeutil.Callee(pass.TypesInfo, callExpr).(*types.Func); ok {
+		info.methodName = fn.Name()
+	}
+
+	var tokenFields []string
+	if output, ok := pkg.Scope().Lookup(info.typeName).(*types.TypeName); ok {
+		tokenFields = paginationTokenFieldsOf(inv.cfg, output.Type(), info.serviceName)
+	}
+	return inv.record(pass, callExpr, info, tokenFields, variable, StatusPaginator)
+}
+
+// flagGated returns the positions of the call sites whose findings depend on flags that
+// are off. Manual loops are only validated with -strict-loops, and their token reads only
+// checked with -strict-token-use, so they have no finding without these flags.
+func (inv *inventory) flagGated() []token.Pos {
+	if inv.cfg.StrictLoops && inv.cfg.StrictTokenUse {
+		return nil
+	}
+	var gated []token.Pos
+	for _, site := range inv.sites {
+		if site.Status == StatusManualLoop {
+			gated = append(gated, site.Pos)
+		}
+	}
+	return gated
+}
+
+// check runs the checks of site, whose diagnostics are attributed to it.
+// site may be nil for calls that are not recorded.
+func (inv *inventory) check(site *CallSite, checks func()) {
+	inv.current = site
+	defer func() { inv.current = nil }()
+	checks()
+}
+
+// observe returns a copy of pass whose Report updates the status of the call site being
+// checked before reporting the diagnostic. pass must report diagnostics before they
+// are suppressed, so that suppressed findings are observed too.
+func (inv *inventory) observe(pass *analysis.Pass) *analysis.Pass {
+	observing := *pass
+	observing.Report = func(diagnostic analysis.Diagnostic) {
+		if site := inv.current; site != nil {
+			// A finding left unsuppressed makes the call unhandled regardless of the others
+			reason := inv.directives.reason(pass.Fset, diagnostic.Pos)
+			reported := site.Status == StatusUnhandled && site.Rule != ""
+			switch {
+			case reason == "":
+				site.Status, site.Rule, site.Reason = StatusUnhandled, diagnostic.Category, ""
+			case !reported:
+				site.Status, site.Rule, site.Reason = StatusSuppressed, diagnostic.Category, reason
+			}
+		}
+		pass.Report(diagnostic)
+	}
+	return &observing
+}
+
+// callSites returns the recorded call sites in order of position.
+func (inv *inventory) callSites() []CallSite {
+	sites := make([]CallSite, len(inv.sites))
+	for i, site := range inv.sites {
+		sites[i] = *site
+	}
+	slices.SortStableFunc(sites, func(a, b CallSite) int {
+		return int(a.Pos) - int(b.Pos)
+	})
+	return sites
+}
+
+// enclosingFuncName returns the name of the function declaration enclosing pos as in
+// Fingerprint.Function, or empty string at package level.
+func enclosingFuncName(pass *analysis.Pass, pos token.Pos) string {
+	file := fileOf(pass, pos)
+	if file == nil {
+		return ""
+	}
+	path, _ := astutil.PathEnclosingInterval(file, pos, pos)
+	for _, node := range path {
+		if funcDecl, ok := node.(*ast.FuncDecl); ok {
+			return funcDeclName(funcDecl)
+		}
+	}
+	return ""
+}
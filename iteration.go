@@ -0,0 +1,209 @@
+package awspagination
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkPaginatorIteration checks the paginators constructed on the right-hand side of an
+// assignment or variable declaration, and reports a diagnostic if a paginator is:
+//  1. Constructed but never iterated (NextPage is never called)
+//  2. Iterated once (NextPage is not called in a loop conditioned on HasMorePages,
+//     see enclosingPaginatorLoop)
+//  3. Exited unconditionally in the first iteration of its loop
+//
+// Paginators that escape the declaring function (e.g., passed to a function, returned or
+// stored in a struct) are not checked, since they may be iterated elsewhere.
+// The call sites of the constructors are recorded in inv.
+func checkPaginatorIteration(pass *analysis.Pass, inv *inventory, lhs, rhs []ast.Expr, stack []ast.Node) {
+	if len(lhs) != len(rhs) {
+		return
+	}
+
+	for i, rightHandSide := range rhs {
+		callExpr, ok := ast.Unparen(rightHandSide).(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		pkg, operation, ok := paginatorConstructorOf(pass, callExpr)
+		if !ok {
+			continue
+		}
+
+		ident, ok := lhs[i].(*ast.Ident)
+		if !ok || ident.Name == "_" {
+			continue
+		}
+		obj := pass.TypesInfo.ObjectOf(ident)
+		fn := declaringFunc(pass, ident, stack)
+		if obj == nil || fn == nil {
+			continue
+		}
+
+		site := inv.recordIteration(pass, callExpr, pkg, operation, ident.Name)
+		_, body := funcTypeAndBody(fn)
+		inv.check(site, func() { checkPaginatorUsage(pass, callExpr, body, obj) })
+	}
+}
+
+// checkPaginatorUsage reports incomplete iterations of the paginator constructed by
+// callExpr and assigned to obj in body.
+func checkPaginatorUsage(pass *analysis.Pass, callExpr *ast.CallExpr, body ast.Node, obj types.Object) {
+	const prefix = "incomplete paginator iteration: "
+
+	value := newResultValue(pass, body, obj, callExpr.Pos())
+	if valueEscapes(body, value) {
+		return
+	}
+
+	var nextPages []*ast.CallExpr
+	ast.Inspect(body, func(node ast.Node) bool {
+		if call, ok := node.(*ast.CallExpr); ok && isPaginatorCall(call, value, "NextPage") {
+			nextPages = append(nextPages, call)
+		}
+		return true
+	})
+
+	if len(nextPages) == 0 {
+		report(pass, analysis.Diagnostic{
+			Pos:      callExpr.Pos(),
+			Category: ruleIncompleteIteration,
+			Message:  prefix + "paginator " + obj.Name() + " is created but never iterated, so no pages are read",
+		})
+		return
+	}
+
+	reported := make(map[*ast.ForStmt]bool)
+	for _, nextPage := range nextPages {
+		loop := enclosingPaginatorLoop(body, value, nextPage.Pos())
+		if loop == nil {
+			report(pass, analysis.Diagnostic{
+				Pos:      nextPage.Pos(),
+				Category: ruleIncompleteIteration,
+				Message: prefix + obj.Name() + ".NextPage is not called in a loop conditioned on " +
+					obj.Name() + ".HasMorePages, so only the first page is read",
+			})
+			continue
+		}
+
+		if exit := unconditionalExit(loop); exit != nil && !reported[loop] {
+			reported[loop] = true
+			report(pass, analysis.Diagnostic{
+				Pos:      exit.Pos(),
+				Category: ruleIncompleteIteration,
+				Message:  prefix + "loop over " + obj.Name() + " exits unconditionally in the first iteration, so only the first page is read",
+			})
+		}
+	}
+}
+
+// isPaginatorCall reports whether callExpr calls the method named method on the paginator value.
+func isPaginatorCall(callExpr *ast.CallExpr, value *resultValue, method string) bool {
+	sel, ok := callExpr.Fun.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == method && value.refersTo(sel.X)
+}
+
+// enclosingPaginatorLoop returns the innermost for statement in body enclosing pos that is
+// conditioned on HasMorePages of the paginator value, or nil if none.
+// A loop is conditioned on HasMorePages if its condition calls it
+// (for paginator.HasMorePages()) or if an if statement calling it leaves the loop
+// (if !paginator.HasMorePages() { break }), see leavesLoop.
+func enclosingPaginatorLoop(body ast.Node, value *resultValue, pos token.Pos) *ast.ForStmt {
+	var loop *ast.ForStmt
+	ast.Inspect(body, func(node ast.Node) bool {
+		if node == nil || pos < node.Pos() || node.End() <= pos {
+			return false
+		}
+		if forStmt, ok := node.(*ast.ForStmt); ok && conditionedOnHasMorePages(body, forStmt, value) {
+			loop = forStmt
+		}
+		return true
+	})
+	return loop
+}
+
+// conditionedOnHasMorePages reports whether loop stops when HasMorePages of the paginator
+// value returns false, see enclosingPaginatorLoop.
+func conditionedOnHasMorePages(body ast.Node, loop *ast.ForStmt, value *resultValue) bool {
+	if loop.Cond != nil && callsHasMorePages(loop.Cond, value) {
+		return true
+	}
+	labels := enclosingLabels(body, loop)
+	return slices.ContainsFunc(loopIfStmts(loop), func(ifStmt loopIfStmt) bool {
+		return callsHasMorePages(ifStmt.Cond, value) && leavesLoop(ifStmt.IfStmt, ifStmt.nested, labels)
+	})
+}
+
+// callsHasMorePages reports whether expr calls HasMorePages on the paginator value.
+func callsHasMorePages(expr ast.Expr, value *resultValue) bool {
+	calls := false
+	ast.Inspect(expr, func(node ast.Node) bool {
+		if call, ok := node.(*ast.CallExpr); ok && isPaginatorCall(call, value, "HasMorePages") {
+			calls = true
+		}
+		return !calls
+	})
+	return calls
+}
+
+// unconditionalExit returns the first statement at the top level of the loop body that
+// leaves the loop (a return or break statement), or nil if the loop body may run again.
+// Statements nested in if, switch or select statements are conditional and not considered.
+func unconditionalExit(loop *ast.ForStmt) ast.Stmt {
+	for _, stmt := range loop.Body.List {
+		switch stmt := stmt.(type) {
+		case *ast.ReturnStmt:
+			return stmt
+		case *ast.BranchStmt:
+			if stmt.Tok == token.BREAK {
+				return stmt
+			}
+		}
+	}
+	return nil
+}
+
+// valueEscapes reports whether the value (e.g., a paginator) leaves the reach of the function
+// body, by being passed to a function, returned, sent on a channel, stored in a composite
+// literal or assigned to something other than a local variable.
+func valueEscapes(body ast.Node, value *resultValue) bool {
+	escapes := false
+	escaping := func(exprs ...ast.Expr) {
+		for _, expr := range exprs {
+			if kv, ok := expr.(*ast.KeyValueExpr); ok {
+				expr = kv.Value
+			}
+			if value.refersTo(ast.Unparen(expr)) {
+				escapes = true
+			}
+		}
+	}
+
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.CallExpr:
+			escaping(node.Args...)
+		case *ast.ReturnStmt:
+			escaping(node.Results...)
+		case *ast.SendStmt:
+			escaping(node.Value)
+		case *ast.CompositeLit:
+			escaping(node.Elts...)
+		case *ast.AssignStmt:
+			if len(node.Lhs) != len(node.Rhs) {
+				break
+			}
+			for i, lhs := range node.Lhs {
+				if _, ok := lhs.(*ast.Ident); !ok {
+					escaping(node.Rhs[i])
+				}
+			}
+		}
+		return !escapes
+	})
+	return escapes
+}
@@ -0,0 +1,149 @@
+package awspagination
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// pageSizeFields are the input fields limiting the number of items of a page.
+// A call explicitly setting one of them may intentionally read a single page.
+var pageSizeFields = []string{"MaxResults", "MaxItems", "Limit", "MaxKeys", "MaxRecords"}
+
+// limitPolicy decides how calls intentionally limited to a single page are handled,
+// see limitedCall.
+type limitPolicy string
+
+const (
+	// limitPolicyReport reports limited calls like any other call (default).
+	limitPolicyReport limitPolicy = "report"
+
+	// limitPolicyInfo reports limited calls with an informational diagnostic.
+	limitPolicyInfo limitPolicy = "info"
+
+	// limitPolicyIgnore does not report limited calls.
+	limitPolicyIgnore limitPolicy = "ignore"
+)
+
+// limitPolicies are the valid values of limitPolicy.
+var limitPolicies = []limitPolicy{limitPolicyReport, limitPolicyInfo, limitPolicyIgnore}
+
+func (p *limitPolicy) String() string {
+	if *p == "" {
+		return string(limitPolicyReport)
+	}
+	return string(*p)
+}
+
+func (p *limitPolicy) Set(value string) error {
+	policy := limitPolicy(value)
+	if !slices.Contains(limitPolicies, policy) {
+		return fmt.Errorf("invalid limited-calls policy %q: must be one of %v", value, limitPolicies)
+	}
+	*p = policy
+	return nil
+}
+
+// limitedCall returns the page-size field set on the input of a List API call whose result
+// is intentionally limited to a single page, or empty string if the call is not limited.
+// A call is limited when:
+//  1. Its input is a composite literal (or a variable initialized with one in fn) that
+//     explicitly sets one of pageSizeFields (e.g., &ecs.ListTasksInput{MaxResults: aws.Int32(10)})
+//  2. The result assigned to obj is only used within fn: it is not returned, passed to
+//     another function or stored elsewhere, since the receiver may expect all pages
+func limitedCall(pass *analysis.Pass, fn ast.Node, obj types.Object, callExpr *ast.CallExpr) string {
+	if fn == nil {
+		return ""
+	}
+	_, body := funcTypeAndBody(fn)
+	if body == nil {
+		return ""
+	}
+
+	field := pageSizeFieldOf(inputLiteral(pass, body, inputArg(pass, callExpr)))
+	if field == "" {
+		return ""
+	}
+	if valueEscapes(body, newResultValue(pass, body, obj, callExpr.Pos())) {
+		return ""
+	}
+	return field
+}
+
+// inputLiteral returns the composite literal of the input argument input, either written
+// in place or assigned to the input variable when it is declared in body.
+// Returns nil if the input is not given by a composite literal.
+func inputLiteral(pass *analysis.Pass, body ast.Node, input ast.Expr) *ast.CompositeLit {
+	literal := func(expr ast.Expr) *ast.CompositeLit {
+		expr = ast.Unparen(expr)
+		if unary, ok := expr.(*ast.UnaryExpr); ok {
+			expr = ast.Unparen(unary.X)
+		}
+		lit, _ := expr.(*ast.CompositeLit)
+		return lit
+	}
+
+	if input == nil {
+		return nil
+	}
+	ident, ok := ast.Unparen(input).(*ast.Ident)
+	if !ok {
+		return literal(input)
+	}
+
+	obj := pass.TypesInfo.Uses[ident]
+	if obj == nil {
+		return nil
+	}
+	var lit *ast.CompositeLit
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range node.Lhs {
+				if id, ok := lhs.(*ast.Ident); ok && pass.TypesInfo.Defs[id] == obj && i < len(node.Rhs) {
+					lit = literal(node.Rhs[i])
+				}
+			}
+		case *ast.ValueSpec:
+			for i, name := range node.Names {
+				if pass.TypesInfo.Defs[name] == obj && i < len(node.Values) {
+					lit = literal(node.Values[i])
+				}
+			}
+		}
+		return lit == nil
+	})
+	return lit
+}
+
+// pageSizeFieldOf returns the first of pageSizeFields explicitly set by lit, or empty
+// string if none is set.
+func pageSizeFieldOf(lit *ast.CompositeLit) string {
+	if lit == nil {
+		return ""
+	}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if key, ok := kv.Key.(*ast.Ident); ok && slices.Contains(pageSizeFields, key.Name) {
+			return key.Name
+		}
+	}
+	return ""
+}
+
+// reportLimitedCall reports a call intentionally limited by the page-size field with an
+// informational diagnostic, for the info policy.
+func reportLimitedCall(pass *analysis.Pass, callExpr *ast.CallExpr, field string, info apiCallInfo) {
+	report(pass, analysis.Diagnostic{
+		Pos:      callExpr.Pos(),
+		Category: ruleLimitedCall,
+		Message: "info: " + callName(info) + " is limited to a single page by " + field +
+			", so further pages are intentionally not read",
+	})
+}
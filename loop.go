@@ -0,0 +1,393 @@
+package awspagination
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkManualLoop validates the manual pagination loop around a List API call whose
+// pagination token is read, and reports a diagnostic for each missing piece:
+//  1. The call is made inside a for loop
+//  2. The token is fed back into the corresponding field of the input passed to the call,
+//     as given by the token field mappings of the operation
+//  3. The loop stops when the token is empty, or when a flag of the result tells that it
+//     is the last page (e.g., S3 IsTruncated)
+//
+// Tokens copied into local variables in the loop (e.g., token = result.NextToken) count
+// as the token itself, see loopTokens.
+//
+// fn is the function declaring the variable obj that the result is assigned to.
+// The feedback check is skipped when the token field mappings are unknown.
+func checkManualLoop(pass *analysis.Pass, callExpr *ast.CallExpr, fn ast.Node, obj types.Object, tokenFields []string, info apiCallInfo) {
+	const prefix = "incomplete pagination loop for AWS SDK List API call: "
+
+	_, body := funcTypeAndBody(fn)
+	loop := enclosingForStmt(body, callExpr.Pos())
+	if loop == nil {
+		report(pass, analysis.Diagnostic{
+			Pos:      callExpr.Pos(),
+			Category: ruleIncompleteLoop,
+			Message:  prefix + callName(info) + " is not called in a loop, so only the first page is read",
+		})
+		return
+	}
+
+	tokens := newLoopTokens(pass, loop, newResultValue(pass, body, obj, callExpr.Pos()))
+	input := inputArg(pass, callExpr)
+
+	if len(info.fieldMappings) > 0 && !feedsTokenBack(pass, loop, tokens, input, info.fieldMappings) {
+		mapping := info.fieldMappings[0]
+		report(pass, analysis.Diagnostic{
+			Pos:      callExpr.Pos(),
+			Category: ruleIncompleteLoop,
+			Message: prefix + obj.Name() + "." + mapping.output + " is not fed back into " +
+				inputName(input) + "." + mapping.input,
+		})
+	}
+
+	if !stopsOnEmptyToken(loop, enclosingLabels(body, loop), tokens, slices.Concat(tokenFields, lastPageFlags(obj.Type()))) {
+		report(pass, analysis.Diagnostic{
+			Pos:      callExpr.Pos(),
+			Category: ruleIncompleteLoop,
+			Message:  prefix + "loop does not stop when " + obj.Name() + "." + tokenFields[0] + " is empty",
+		})
+	}
+}
+
+// callName returns the name of the called API method for diagnostics.
+func callName(info apiCallInfo) string {
+	if info.methodName == "" {
+		return "the API"
+	}
+	return info.methodName
+}
+
+// enclosingForStmt returns the innermost for statement in body enclosing pos, or nil if none.
+// Range statements are not considered, since they iterate over a fixed set of values
+// and cannot be driven by a pagination token.
+func enclosingForStmt(body ast.Node, pos token.Pos) *ast.ForStmt {
+	var loop *ast.ForStmt
+	ast.Inspect(body, func(node ast.Node) bool {
+		if node == nil || pos < node.Pos() || node.End() <= pos {
+			return false
+		}
+		if forStmt, ok := node.(*ast.ForStmt); ok {
+			loop = forStmt
+		}
+		return true
+	})
+	return loop
+}
+
+// lastPageFlagPrefixes are the name prefixes of the boolean fields of outputs telling whether
+// more pages follow (e.g., S3 IsTruncated, Kinesis HasMoreShards), which stop a manual loop
+// like an empty token.
+var lastPageFlagPrefixes = []string{"IsTruncated", "HasMore"}
+
+// lastPageFlags returns the fields of the output type t telling whether more pages follow,
+// see lastPageFlagPrefixes.
+func lastPageFlags(t types.Type) []string {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+
+	var flags []string
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		if !slices.ContainsFunc(lastPageFlagPrefixes, func(prefix string) bool { return strings.HasPrefix(field.Name(), prefix) }) {
+			continue
+		}
+		// AWS SDK v2 flags are bool or *bool, AWS SDK v1 flags are *bool
+		fieldType := field.Type()
+		if ptr, ok := fieldType.(*types.Pointer); ok {
+			fieldType = ptr.Elem()
+		}
+		if basic, ok := fieldType.Underlying().(*types.Basic); ok && basic.Kind() == types.Bool {
+			flags = append(flags, field.Name())
+		}
+	}
+	return flags
+}
+
+// loopTokens tracks the pagination tokens of a result within a manual loop: the token
+// fields of the result, and the local variables the loop copies them into
+// (e.g., token = result.NextToken), as in:
+//
+//	var token *string
+//	for {
+//		result, err := client.ListTasks(ctx, &ecs.ListTasksInput{NextToken: token})
+//		...
+//		token = result.NextToken
+//		if token == nil {
+//			break
+//		}
+//	}
+type loopTokens struct {
+	pass  *analysis.Pass
+	value *resultValue
+
+	// vars maps the variables holding a token to the token field they are copied from.
+	vars map[types.Object]string
+}
+
+// newLoopTokens collects the variables that loop assigns the pagination tokens of value to.
+func newLoopTokens(pass *analysis.Pass, loop *ast.ForStmt, value *resultValue) *loopTokens {
+	tokens := &loopTokens{pass: pass, value: value, vars: make(map[types.Object]string)}
+
+	addVar := func(lhs, rhs ast.Expr) {
+		ident, ok := lhs.(*ast.Ident)
+		if !ok || ident.Name == "_" {
+			return
+		}
+		obj := pass.TypesInfo.ObjectOf(ident)
+		if obj == nil {
+			return
+		}
+		ast.Inspect(rhs, func(node ast.Node) bool {
+			if sel, ok := node.(*ast.SelectorExpr); ok && value.refersTo(sel.X) {
+				tokens.vars[obj] = sel.Sel.Name
+				return false
+			}
+			return true
+		})
+	}
+
+	ast.Inspect(loop, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.AssignStmt:
+			if len(node.Lhs) == len(node.Rhs) {
+				for i, lhs := range node.Lhs {
+					addVar(lhs, node.Rhs[i])
+				}
+			}
+		case *ast.ValueSpec:
+			if len(node.Names) == len(node.Values) {
+				for i, name := range node.Names {
+					addVar(name, node.Values[i])
+				}
+			}
+		}
+		return true
+	})
+	return tokens
+}
+
+// reads reports whether expr reads one of the pagination token fields tokenFields of the
+// result, directly or through a variable holding it.
+func (t *loopTokens) reads(expr ast.Node, tokenFields []string) bool {
+	reads := false
+	ast.Inspect(expr, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.SelectorExpr:
+			if slices.Contains(tokenFields, node.Sel.Name) && t.value.refersTo(node.X) {
+				reads = true
+			}
+		case *ast.Ident:
+			if field, ok := t.vars[t.pass.TypesInfo.Uses[node]]; ok && slices.Contains(tokenFields, field) {
+				reads = true
+			}
+		}
+		return !reads
+	})
+	return reads
+}
+
+// feedsTokenBack reports whether loop passes one of the pagination tokens to the input field
+// given by its mapping, either through an input literal built in the loop
+// (client.ListTasks(ctx, &ecs.ListTasksInput{NextToken: token})), field by field
+// (input.NextToken = result.NextToken) or by rebuilding the input
+// (input = &ecs.ListTasksInput{NextToken: result.NextToken}).
+func feedsTokenBack(pass *analysis.Pass, loop *ast.ForStmt, tokens *loopTokens, input ast.Expr, mappings []tokenFieldMapping) bool {
+	if literalFeedsToken(input, tokens, mappings) {
+		return true
+	}
+
+	ident, ok := input.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	inputObj := pass.TypesInfo.ObjectOf(ident)
+
+	fed := false
+	ast.Inspect(loop, func(node ast.Node) bool {
+		assignStmt, ok := node.(*ast.AssignStmt)
+		if !ok || len(assignStmt.Lhs) != len(assignStmt.Rhs) {
+			return true
+		}
+		for i, lhs := range assignStmt.Lhs {
+			rhs := assignStmt.Rhs[i]
+			switch lhs := lhs.(type) {
+			case *ast.SelectorExpr:
+				// input.NextToken = result.NextToken
+				if isObject(pass, lhs.X, inputObj) && feedsField(lhs.Sel.Name, rhs, tokens, mappings) {
+					fed = true
+				}
+			case *ast.Ident:
+				// input = &ecs.ListTasksInput{NextToken: result.NextToken}
+				if isObject(pass, lhs, inputObj) && literalFeedsToken(rhs, tokens, mappings) {
+					fed = true
+				}
+			}
+		}
+		return !fed
+	})
+	return fed
+}
+
+// feedsField reports whether assigning expr to the input field named inputField feeds
+// back the pagination token mapped to that field.
+func feedsField(inputField string, expr ast.Expr, tokens *loopTokens, mappings []tokenFieldMapping) bool {
+	for _, mapping := range mappings {
+		if mapping.input == inputField && tokens.reads(expr, []string{mapping.output}) {
+			return true
+		}
+	}
+	return false
+}
+
+// literalFeedsToken reports whether expr is a composite literal (or its address) setting
+// an input field from the pagination token mapped to it.
+func literalFeedsToken(expr ast.Expr, tokens *loopTokens, mappings []tokenFieldMapping) bool {
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = unary.X
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return false
+	}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if key, ok := kv.Key.(*ast.Ident); ok && feedsField(key.Name, kv.Value, tokens, mappings) {
+			return true
+		}
+	}
+	return false
+}
+
+// stopsOnEmptyToken reports whether loop checks one of the fields stopFields of the result
+// (its pagination tokens and flags telling whether more pages follow), directly or through
+// a variable holding it, either in its condition (for result.NextToken != nil) or in an
+// if statement leaving the loop (if result.NextToken == nil { break }), see leavesLoop.
+// labels are the labels of the loop and of the statements enclosing it.
+func stopsOnEmptyToken(loop *ast.ForStmt, labels []string, tokens *loopTokens, stopFields []string) bool {
+	if loop.Cond != nil && tokens.reads(loop.Cond, stopFields) {
+		return true
+	}
+	return slices.ContainsFunc(loopIfStmts(loop), func(ifStmt loopIfStmt) bool {
+		return tokens.reads(ifStmt.Cond, stopFields) && leavesLoop(ifStmt.IfStmt, ifStmt.nested, labels)
+	})
+}
+
+// loopIfStmt is an if statement in the body of a loop.
+type loopIfStmt struct {
+	*ast.IfStmt
+
+	// nested tells whether the if statement is nested in another for, range, switch or
+	// select statement within the loop, which an unlabeled break would leave instead.
+	nested bool
+}
+
+// loopIfStmts returns the if statements in the body of loop, outside of function literals.
+func loopIfStmts(loop *ast.ForStmt) []loopIfStmt {
+	var ifStmts []loopIfStmt
+	var visit func(root ast.Node, nested bool)
+	visit = func(root ast.Node, nested bool) {
+		ast.Inspect(root, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.IfStmt:
+				ifStmts = append(ifStmts, loopIfStmt{IfStmt: node, nested: nested})
+			case *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+				if node != root {
+					visit(node, true)
+					return false
+				}
+			}
+			return true
+		})
+	}
+	visit(loop.Body, false)
+	return ifStmts
+}
+
+// leavesLoop reports whether the branches of ifStmt leave the enclosing loop: a return or
+// goto statement, a break labeled with one of labels (the loop or a statement enclosing it),
+// or an unlabeled break that is not nested in another for, range, switch or select statement.
+// nested tells whether ifStmt itself is nested in such a statement within the loop.
+// Function literals are not descended into.
+func leavesLoop(ifStmt *ast.IfStmt, nested bool, labels []string) bool {
+	leaves := false
+	var visit func(root ast.Node, nested bool)
+	visit = func(root ast.Node, nested bool) {
+		ast.Inspect(root, func(node ast.Node) bool {
+			if leaves {
+				return false
+			}
+			switch node := node.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.ReturnStmt:
+				leaves = true
+			case *ast.BranchStmt:
+				switch {
+				case node.Tok == token.GOTO:
+					leaves = true
+				case node.Tok == token.BREAK && node.Label != nil:
+					leaves = slices.Contains(labels, node.Label.Name)
+				case node.Tok == token.BREAK:
+					leaves = !nested
+				}
+			case *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+				if node != root {
+					visit(node, true)
+					return false
+				}
+			}
+			return true
+		})
+	}
+	visit(ifStmt.Body, nested)
+	if ifStmt.Else != nil {
+		visit(ifStmt.Else, nested)
+	}
+	return leaves
+}
+
+// enclosingLabels returns the labels of loop and of the statements enclosing it in body,
+// which a labeled break in the loop leaves the loop with.
+func enclosingLabels(body ast.Node, loop *ast.ForStmt) []string {
+	var labels []string
+	ast.Inspect(body, func(node ast.Node) bool {
+		if node == nil || loop.Pos() < node.Pos() || node.End() < loop.End() {
+			return false
+		}
+		switch node := node.(type) {
+		case *ast.FuncLit:
+			// Labels are scoped to the function
+			labels = nil
+		case *ast.LabeledStmt:
+			labels = append(labels, node.Label.Name)
+		}
+		return true
+	})
+	return labels
+}
+
+// isObject reports whether expr is an identifier referring to obj.
+func isObject(pass *analysis.Pass, expr ast.Expr, obj types.Object) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && obj != nil && pass.TypesInfo.ObjectOf(ident) == obj
+}
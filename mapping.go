@@ -0,0 +1,122 @@
+package awspagination
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// irregularInputTokenFields maps pagination token fields of outputs to the input fields
+// they are fed back into, for the fields that do not follow the naming conventions
+// handled by inputFieldCandidates.
+var irregularInputTokenFields = map[string]string{
+	"LastEvaluatedKey": "ExclusiveStartKey", // DynamoDB
+	"NextPageMarker":   "Marker",            // Route53Domains
+}
+
+// tokenFieldMapping maps a pagination token field of an output to the input field it is
+// fed back into on the next request (e.g., NextMarker -> Marker).
+type tokenFieldMapping struct {
+	output string
+	input  string
+}
+
+// assignment returns the statement feeding the token back into the input
+// (e.g., "input.Marker = result.NextMarker").
+func (m tokenFieldMapping) assignment(inputName, varName string) string {
+	return inputName + "." + m.input + " = " + varName + "." + m.output
+}
+
+// inputFieldCandidates returns the input field names a pagination token field may be
+// fed back into, in order of preference:
+//  1. Irregular names (LastEvaluatedKey -> ExclusiveStartKey)
+//  2. The same name (NextToken -> NextToken, Marker -> Marker, Position -> Position)
+//  3. The name without the Next prefix (NextMarker -> Marker, NextContinuationToken -> ContinuationToken)
+//  4. The name with Next replaced by Start (NextRecordName -> StartRecordName)
+func inputFieldCandidates(tokenField string) []string {
+	var candidates []string
+	if renamed, ok := irregularInputTokenFields[tokenField]; ok {
+		candidates = append(candidates, renamed)
+	}
+	candidates = append(candidates, tokenField)
+	if name, ok := strings.CutPrefix(tokenField, "Next"); ok && name != "" {
+		candidates = append(candidates, name, "Start"+name)
+	}
+	return candidates
+}
+
+// extractFieldMappings derives the mappings from the pagination token fields of the output
+// of a call to the fields of its input type.
+// The input type is the <Operation>Input type declared next to the <Operation>Output result
+// type in the service package, falling back to the type of the input argument of the call.
+// Token fields without a matching input field (e.g., Route53 IsTruncated) are not mapped.
+// Returns nil if the input type cannot be determined.
+func extractFieldMappings(pass *analysis.Pass, callExpr *ast.CallExpr, resultType types.Type, tokenFields []string) []tokenFieldMapping {
+	inputType := operationInputType(resultType)
+	if inputType == nil {
+		if input := inputArg(pass, callExpr); input != nil {
+			inputType = pass.TypesInfo.TypeOf(input)
+		}
+	}
+	if inputType == nil {
+		return nil
+	}
+
+	var mappings []tokenFieldMapping
+	for _, tokenField := range tokenFields {
+		for _, candidate := range inputFieldCandidates(tokenField) {
+			if hasSpecificField(inputType, candidate, make(map[types.Type]bool)) {
+				mappings = append(mappings, tokenFieldMapping{output: tokenField, input: candidate})
+				break
+			}
+		}
+	}
+	return mappings
+}
+
+// operationInputType returns the <Operation>Input type declared in the package of the
+// <Operation>Output type t, or nil if t is not an operation output type.
+func operationInputType(t types.Type) types.Type {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return nil
+	}
+
+	operation, ok := strings.CutSuffix(named.Obj().Name(), "Output")
+	if !ok {
+		return nil
+	}
+	input, ok := named.Obj().Pkg().Scope().Lookup(operation + "Input").(*types.TypeName)
+	if !ok {
+		return nil
+	}
+	return input.Type()
+}
+
+// inputArg returns the argument of callExpr holding the API input (e.g., *ecs.ListTasksInput),
+// or nil if the call has no such argument.
+func inputArg(pass *analysis.Pass, callExpr *ast.CallExpr) ast.Expr {
+	for _, arg := range callExpr.Args {
+		t := pass.TypesInfo.TypeOf(arg)
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		if named, ok := t.(*types.Named); ok && strings.HasSuffix(named.Obj().Name(), "Input") {
+			return arg
+		}
+	}
+	return nil
+}
+
+// inputName returns the name of the input variable for diagnostics.
+func inputName(input ast.Expr) string {
+	if ident, ok := input.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return "input"
+}
@@ -1,6 +1,7 @@
 package awspagination
 
 import (
+	"slices"
 	"strings"
 	"testing"
 )
@@ -22,6 +23,10 @@ func TestErrorMessageFormat(t *testing.T) {
 				methodName:  "ListTasks",
 				serviceName: "ecs",
 				typeName:    "ListTasksOutput",
+				inputName:   "input",
+				fieldMappings: []tokenFieldMapping{
+					{output: "NextToken", input: "NextToken"},
+				},
 			},
 			wantParts: []string{
 				"missing pagination handling for AWS SDK List API call",
@@ -30,6 +35,7 @@ func TestErrorMessageFormat(t *testing.T) {
 				"NewListTasksPaginator",
 				"loop with",
 				"result.NextToken",
+				"feeding it back with input.NextToken = result.NextToken.",
 			},
 		},
 		{
@@ -40,6 +46,10 @@ func TestErrorMessageFormat(t *testing.T) {
 				methodName:  "ListObjectsV2",
 				serviceName: "s3",
 				typeName:    "ListObjectsV2Output",
+				inputName:   "params",
+				fieldMappings: []tokenFieldMapping{
+					{output: "NextContinuationToken", input: "ContinuationToken"},
+				},
 			},
 			wantParts: []string{
 				"missing pagination handling for AWS SDK List API call",
@@ -48,6 +58,45 @@ func TestErrorMessageFormat(t *testing.T) {
 				"NewListObjectsV2Paginator",
 				"loop with",
 				"output.NextContinuationToken",
+				"params.ContinuationToken = output.NextContinuationToken",
+			},
+		},
+		{
+			name:        "DynamoDB Scan with irregular input field",
+			tokenFields: []string{"LastEvaluatedKey"},
+			varName:     "",
+			info: apiCallInfo{
+				methodName:  "Scan",
+				serviceName: "dynamodb",
+				typeName:    "ScanOutput",
+				fieldMappings: []tokenFieldMapping{
+					{output: "LastEvaluatedKey", input: "ExclusiveStartKey"},
+				},
+			},
+			wantParts: []string{
+				"result has LastEvaluatedKey field",
+				"NewScanPaginator",
+				"input.ExclusiveStartKey = result.LastEvaluatedKey",
+			},
+		},
+		{
+			name:        "AWS SDK v1 WithContext method",
+			tokenFields: []string{"Marker"},
+			varName:     "out",
+			info: apiCallInfo{
+				methodName:  "ListUsersWithContext",
+				serviceName: "iam",
+				typeName:    "ListUsersOutput",
+				inputName:   "input",
+				fieldMappings: []tokenFieldMapping{
+					{output: "Marker", input: "Marker"},
+				},
+				sdkV1: true,
+			},
+			wantParts: []string{
+				"result has Marker field",
+				"Use ListUsersPagesWithContext or loop with out.Marker",
+				"input.Marker = out.Marker",
 			},
 		},
 		{
@@ -72,6 +121,12 @@ func TestErrorMessageFormat(t *testing.T) {
 				methodName:  "ListResourceRecordSets",
 				serviceName: "route53",
 				typeName:    "ListResourceRecordSetsOutput",
+				inputName:   "input",
+				fieldMappings: []tokenFieldMapping{
+					{output: "NextRecordName", input: "StartRecordName"},
+					{output: "NextRecordType", input: "StartRecordType"},
+					{output: "NextRecordIdentifier", input: "StartRecordIdentifier"},
+				},
 			},
 			wantParts: []string{
 				"missing pagination handling for AWS SDK List API call",
@@ -80,6 +135,8 @@ func TestErrorMessageFormat(t *testing.T) {
 				"NewListResourceRecordSetsPaginator",
 				"loop with",
 				"result.IsTruncated",
+				"input.StartRecordName = result.NextRecordName, input.StartRecordType = result.NextRecordType, " +
+					"input.StartRecordIdentifier = result.NextRecordIdentifier.",
 			},
 		},
 	}
@@ -130,3 +187,27 @@ func TestExtractServiceName(t *testing.T) {
 		})
 	}
 }
+
+// TestInputFieldCandidates verifies the input field names derived from pagination token fields
+func TestInputFieldCandidates(t *testing.T) {
+	tests := []struct {
+		tokenField string
+		want       []string
+	}{
+		{tokenField: "NextToken", want: []string{"NextToken", "Token", "StartToken"}},
+		{tokenField: "NextMarker", want: []string{"NextMarker", "Marker", "StartMarker"}},
+		{tokenField: "NextContinuationToken", want: []string{"NextContinuationToken", "ContinuationToken", "StartContinuationToken"}},
+		{tokenField: "NextRecordName", want: []string{"NextRecordName", "RecordName", "StartRecordName"}},
+		{tokenField: "LastEvaluatedKey", want: []string{"ExclusiveStartKey", "LastEvaluatedKey"}},
+		{tokenField: "Marker", want: []string{"Marker"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tokenField, func(t *testing.T) {
+			got := inputFieldCandidates(tt.tokenField)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("inputFieldCandidates(%q) = %v, want %v", tt.tokenField, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package awspagination
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// operationName returns the name of the operation of a List API call (e.g., "ListTasks"),
+// derived from the method name, or from the output type name if the method is unknown.
+// The WithContext suffix of AWS SDK v1 methods is trimmed (ListTasksWithContext -> ListTasks).
+func operationName(info apiCallInfo) string {
+	if info.methodName != "" {
+		if info.sdkV1 {
+			return strings.TrimSuffix(info.methodName, "WithContext")
+		}
+		return info.methodName
+	}
+	return strings.TrimSuffix(info.typeName, "Output")
+}
+
+// matchesOperation reports whether any of patterns matches the operation of a List API call.
+// A pattern has the form [service.]Name, where service is matched against the service name
+// case-insensitively and Name against both the operation name and the output type name
+// (e.g., "ecs.ListClusters", "iam.List*", "ListTasksOutput"). Both parts may contain
+// path.Match wildcards. Patterns without a service match operations of any service.
+func matchesOperation(patterns []string, info apiCallInfo) bool {
+	operation := operationName(info)
+	for _, pattern := range patterns {
+		service, name, ok := strings.Cut(pattern, ".")
+		if !ok {
+			service, name = "*", pattern
+		}
+		if matched, _ := path.Match(strings.ToLower(service), strings.ToLower(info.serviceName)); !matched {
+			continue
+		}
+		for _, candidate := range []string{operation, info.typeName} {
+			if matched, _ := path.Match(name, candidate); matched && candidate != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validateOperationPatterns reports the first malformed pattern of patterns,
+// see matchesOperation.
+func validateOperationPatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		service, name, ok := strings.Cut(pattern, ".")
+		if !ok {
+			service, name = "*", pattern
+		}
+		if service == "" || name == "" || strings.Contains(name, ".") {
+			return fmt.Errorf("invalid operation pattern %q: must be [service.]Operation", pattern)
+		}
+		for _, part := range []string{service, name} {
+			if _, err := path.Match(part, ""); err != nil {
+				return fmt.Errorf("invalid operation pattern %q: %w", pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// operationExcluded reports whether the operation of a List API call is excluded from the
+// checks by cfg: it matches an excluded pattern, and does not match an included one.
+func operationExcluded(cfg *Config, info apiCallInfo) bool {
+	return matchesOperation(cfg.ExcludeOperations, info) && !matchesOperation(cfg.IncludeOperations, info)
+}
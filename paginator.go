@@ -0,0 +1,297 @@
+package awspagination
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// paginatedOperation identifies the AWS SDK operation producing a List API result, so that
+// paginators can be matched against the call they replace.
+type paginatedOperation struct {
+	// pkg is the service package declaring the operation (e.g., .../service/ecs).
+	pkg *types.Package
+
+	// name is the operation name (e.g., "ListTasks").
+	name string
+
+	// client and input are the receiver and input arguments of the call.
+	// Nil if the value does not come from a direct client call (e.g., parameters or wrappers).
+	client ast.Expr
+	input  ast.Expr
+}
+
+// newPaginatedOperation identifies the operation producing a value of the output type t.
+// callExpr is the call producing the value, or nil if the value is a parameter.
+// The operation is derived from the <Operation>Output type name, so that results of
+// wrappers around the API call are matched as well.
+func newPaginatedOperation(pass *analysis.Pass, callExpr *ast.CallExpr, t types.Type) paginatedOperation {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return paginatedOperation{}
+	}
+	name, ok := strings.CutSuffix(named.Obj().Name(), "Output")
+	if !ok {
+		return paginatedOperation{}
+	}
+
+	op := paginatedOperation{pkg: named.Obj().Pkg(), name: name}
+	if callExpr == nil {
+		return op
+	}
+
+	// Only direct calls of the client method carry a client and input to compare
+	// (AWS SDK v1 methods may have a WithContext suffix)
+	if fn, ok := typeutil.Callee(pass.TypesInfo, callExpr).(*types.Func); ok && fn.Pkg() == op.pkg && strings.TrimSuffix(fn.Name(), "WithContext") == name {
+		if sel, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
+			op.client = sel.X
+		}
+		op.input = inputArg(pass, callExpr)
+	}
+	return op
+}
+
+// matchesPaginator reports whether callExpr constructs a paginator for the operation
+// (e.g., ecs.NewListTasksPaginator for ListTasks), resolved through the type checker.
+// For AWS SDK v1, calls of the <Operation>Pages methods of the client count as paginators
+// (e.g., svc.ListUsersPages for ListUsers).
+// For direct client calls, the paginator must also be created from the same input, so that
+// paginators of unrelated calls of the same operation do not count. Only when neither input
+// refers to a variable (e.g., both are composite literals) is the same client enough.
+func (op paginatedOperation) matchesPaginator(pass *analysis.Pass, callExpr *ast.CallExpr) bool {
+	if op.pkg == nil {
+		return false
+	}
+
+	var client, input ast.Expr
+	pkg, operation, ok := paginatorConstructorOf(pass, callExpr)
+	if ok && len(callExpr.Args) >= 2 {
+		client, input = callExpr.Args[0], callExpr.Args[1]
+	} else if !ok {
+		pkg, operation, ok = pagesMethodOf(pass, callExpr)
+		if sel, isSel := callExpr.Fun.(*ast.SelectorExpr); isSel {
+			client = sel.X
+		}
+		input = inputArg(pass, callExpr)
+	}
+	if !ok || pkg != op.pkg || operation != op.name {
+		return false
+	}
+
+	if op.client == nil && op.input == nil {
+		return true
+	}
+	if isVariableRef(pass, op.input) || isVariableRef(pass, input) {
+		return sameValue(pass, op.input, input)
+	}
+	return sameValue(pass, op.client, client)
+}
+
+// paginatorConstructorOf returns the service package and operation of the paginator
+// constructed by callExpr, if callExpr calls a New<Operation>Paginator function declared
+// in an AWS SDK service package.
+func paginatorConstructorOf(pass *analysis.Pass, callExpr *ast.CallExpr) (*types.Package, string, bool) {
+	fn, ok := typeutil.Callee(pass.TypesInfo, callExpr).(*types.Func)
+	if !ok || fn.Pkg() == nil || !isAWSSDKPackage(fn.Pkg().Path()) {
+		return nil, "", false
+	}
+	if fn.Type().(*types.Signature).Recv() != nil {
+		return nil, "", false
+	}
+
+	operation, ok := strings.CutPrefix(fn.Name(), "New")
+	if !ok {
+		return nil, "", false
+	}
+	operation, ok = strings.CutSuffix(operation, "Paginator")
+	if !ok || operation == "" {
+		return nil, "", false
+	}
+	return fn.Pkg(), operation, true
+}
+
+// isPaginatorMethod reports whether callExpr calls a method of an AWS SDK paginator
+// (e.g., (*ecs.ListTasksPaginator).NextPage).
+// Pages returned by paginators are part of a paginated iteration and need no handling.
+func isPaginatorMethod(pass *analysis.Pass, callExpr *ast.CallExpr) bool {
+	fn, ok := typeutil.Callee(pass.TypesInfo, callExpr).(*types.Func)
+	if !ok {
+		return false
+	}
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return false
+	}
+
+	t := recv.Type()
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return isAWSSDKPackage(named.Obj().Pkg().Path()) && strings.HasSuffix(named.Obj().Name(), "Paginator")
+}
+
+// sameValue reports whether a and b refer to the same variable or field, such as
+// client and client, or s.client and s.client.
+// Other expressions (e.g., composite literals) are never considered the same.
+func sameValue(pass *analysis.Pass, a, b ast.Expr) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	a, b = ast.Unparen(a), ast.Unparen(b)
+
+	switch a := a.(type) {
+	case *ast.Ident:
+		b, ok := b.(*ast.Ident)
+		obj := pass.TypesInfo.ObjectOf(a)
+		return ok && obj != nil && obj == pass.TypesInfo.ObjectOf(b)
+	case *ast.SelectorExpr:
+		b, ok := b.(*ast.SelectorExpr)
+		return ok && a.Sel.Name == b.Sel.Name && sameValue(pass, a.X, b.X)
+	case *ast.StarExpr:
+		b, ok := b.(*ast.StarExpr)
+		return ok && sameValue(pass, a.X, b.X)
+	case *ast.UnaryExpr:
+		b, ok := b.(*ast.UnaryExpr)
+		return ok && a.Op == b.Op && sameValue(pass, a.X, b.X)
+	}
+	return false
+}
+
+// isVariableRef reports whether expr refers to a variable or a field of one (e.g., input,
+// s.input or &input), which sameValue can compare.
+func isVariableRef(pass *analysis.Pass, expr ast.Expr) bool {
+	switch expr := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		_, ok := pass.TypesInfo.ObjectOf(expr).(*types.Var)
+		return ok
+	case *ast.SelectorExpr:
+		return isVariableRef(pass, expr.X)
+	case *ast.StarExpr:
+		return isVariableRef(pass, expr.X)
+	case *ast.UnaryExpr:
+		return expr.Op == token.AND && isVariableRef(pass, expr.X)
+	}
+	return false
+}
+
+// paginationTokenFieldsOf returns the pagination token fields of the output type t of a
+// List API call, or nil if the operation does not paginate.
+//
+// For <Operation>Output types of AWS SDK service packages, the SDK's own paginators are the
+// authoritative signal: if a New<Operation>Paginator function (or, for AWS SDK v1, an
+// <Operation>Pages method) exists, the operation paginates even if its token fields have
+// unusual names (e.g., S3 ListParts with NextPartNumberMarker), which are then derived
+// from the input type. Otherwise, the operation does not paginate, even if its output has
+// token fields (e.g., a Marker echoed back from the input), unless they are configured
+// explicitly (see configuredTokenFields).
+// Other types (e.g., custom structs embedding AWS SDK types) are recognized by their
+// token fields alone.
+func paginationTokenFieldsOf(cfg *Config, t types.Type, serviceName string) []string {
+	paginator, known := operationPaginator(t)
+	if known && paginator == nil {
+		return configuredTokenFields(cfg, t, serviceName)
+	}
+
+	fields := getAllPaginationTokenFields(cfg, t, serviceName)
+	if known && len(fields) == 0 {
+		fields = derivedTokenFields(t)
+	}
+	return fields
+}
+
+// configuredTokenFields returns the pagination token fields of the output type t that are
+// configured explicitly through cfg (custom fields and service fields of serviceName), which
+// count as pagination tokens for operations without a paginator as well, since the user
+// declared them (e.g., DynamoDB ListBackups with LastEvaluatedBackupArn).
+func configuredTokenFields(cfg *Config, t types.Type, serviceName string) []string {
+	var fields []string
+	for _, field := range slices.Concat(cfg.CustomTokenFields, cfg.ServiceTokenFields[strings.ToLower(serviceName)]) {
+		if !slices.Contains(fields, field) && hasSpecificField(t, field, make(map[types.Type]bool)) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// operationPaginator returns the New<Operation>Paginator function declared in the package
+// of the <Operation>Output type t, or nil if the operation has no paginator.
+// For AWS SDK v1, which has no paginators, the <Operation>Pages method of the client is
+// returned instead.
+// known is false if t is not an operation output type of an AWS SDK service package.
+func operationPaginator(t types.Type) (paginator *types.Func, known bool) {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || !isAWSSDKPackage(named.Obj().Pkg().Path()) {
+		return nil, false
+	}
+	operation, ok := strings.CutSuffix(named.Obj().Name(), "Output")
+	if !ok {
+		return nil, false
+	}
+
+	if isAWSSDKv1Package(named.Obj().Pkg().Path()) {
+		return operationPagesMethod(named.Obj().Pkg(), operation), true
+	}
+	paginator, _ = named.Obj().Pkg().Scope().Lookup("New" + operation + "Paginator").(*types.Func)
+	return paginator, true
+}
+
+// derivedTokenFields returns the fields of the <Operation>Output type t that are fed back
+// into a field of the <Operation>Input type, for operations whose token fields have
+// unusual names. Fields naming the next page (Next*) are preferred over other *Token fields.
+func derivedTokenFields(t types.Type) []string {
+	inputType := operationInputType(t)
+	if inputType == nil {
+		return nil
+	}
+
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+
+	var next, tokens []string
+	for i := 0; i < st.NumFields(); i++ {
+		name := st.Field(i).Name()
+		if !fedBackIntoInput(inputType, name) {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(name, "Next") && name != "Next":
+			next = append(next, name)
+		case strings.Contains(name, "Token"):
+			tokens = append(tokens, name)
+		}
+	}
+	if len(next) > 0 {
+		return next
+	}
+	return tokens
+}
+
+// fedBackIntoInput reports whether the output field tokenField has a matching field in inputType.
+func fedBackIntoInput(inputType types.Type, tokenField string) bool {
+	for _, candidate := range inputFieldCandidates(tokenField) {
+		if hasSpecificField(inputType, candidate, make(map[types.Type]bool)) {
+			return true
+		}
+	}
+	return false
+}
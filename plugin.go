@@ -0,0 +1,116 @@
+package awspagination
+
+import (
+	"github.com/golangci/plugin-module-register/register"
+	"golang.org/x/tools/go/analysis"
+)
+
+// pluginName is the name the module plugin is registered under, which is the key of
+// the linter settings in .golangci.yml.
+const pluginName = "awspagination"
+
+func init() {
+	register.Plugin(pluginName, newPlugin)
+}
+
+// plugin is the golangci-lint module plugin of the analyzer.
+// golangci-lint creates it from the settings of .golangci.yml through the register
+// package, and builds the analyzers to run from it.
+type plugin struct {
+	config Config
+}
+
+var _ register.LinterPlugin = (*plugin)(nil)
+
+// newPlugin creates the module plugin from the raw settings of .golangci.yml.
+// Unknown settings and invalid values are reported as errors, so that typos in the
+// configuration do not go unnoticed.
+func newPlugin(settings any) (register.LinterPlugin, error) {
+	s, err := register.DecodeSettings[Settings](settings)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert []string to stringSliceFlag
+	cfg := Config{
+		CustomTokenFields: stringSliceFlag(s.CustomFields),
+		IncludeTests:      s.IncludeTests,
+		IncludeGenerated:  s.IncludeGenerated,
+		ExcludePaths:      stringSliceFlag(s.ExcludePaths),
+		StrictLoops:       s.StrictLoops,
+		StrictTokenUse:    s.StrictTokenUse,
+		ExcludeOperations: stringSliceFlag(s.ExcludeOperations),
+		IncludeOperations: stringSliceFlag(s.IncludeOperations),
+		Explain:           s.Explain,
+	}
+	if err := validatePathPatterns(s.ExcludePaths); err != nil {
+		return nil, err
+	}
+	for _, patterns := range [][]string{s.ExcludeOperations, s.IncludeOperations} {
+		if err := validateOperationPatterns(patterns); err != nil {
+			return nil, err
+		}
+	}
+	for service, fields := range s.ServiceFields {
+		if err := cfg.ServiceTokenFields.add(service, fields); err != nil {
+			return nil, err
+		}
+	}
+	if s.LimitedCalls != "" {
+		if err := cfg.LimitedCalls.Set(s.LimitedCalls); err != nil {
+			return nil, err
+		}
+	}
+
+	return &plugin{config: cfg}, nil
+}
+
+// BuildAnalyzers returns an analyzer configured with the settings of the plugin.
+func (p *plugin) BuildAnalyzers() ([]*analysis.Analyzer, error) {
+	return []*analysis.Analyzer{NewAnalyzer(p.config)}, nil
+}
+
+// GetLoadMode returns register.LoadModeTypesInfo, since the analyzer uses pass.TypesInfo
+// to check types.
+func (p *plugin) GetLoadMode() string {
+	return register.LoadModeTypesInfo
+}
+
+// New creates a new analyzer instance for golangci-lint module plugin integration.
+// It decodes settings from YAML configuration and returns an analyzer configured with them,
+// leaving Analyzer and any other instance untouched.
+// golangci-lint loads the plugin registered in init instead; New builds the same analyzers
+// for callers that do not go through the register package.
+//
+// For direct integration via Analyzer.Flags, this function is not used.
+// The two integration methods are mutually exclusive:
+// - Module plugin: Uses the registered plugin (or New) with Settings from YAML
+// - Direct integration: Uses Analyzer.Flags with command-line flags
+//
+// Example YAML configuration:
+//
+//	linters:
+//	  settings:
+//	    custom:
+//	      awspagination:
+//	        type: "module"
+//	        settings:
+//	          custom-fields: ["MyToken", "CustomNextToken"]
+//	          include-tests: true
+//	          include-generated: false
+//	          exclude-paths: ["internal/mocks/**", "**/zz_generated*.go"]
+//	          strict-loops: true
+//	          strict-token-use: true
+//	          limited-calls: info
+//	          service-fields:
+//	            cloudwatchlogs: ["NextForwardToken"]
+//	          exclude-operations: ["ecs.ListClusters", "iam.List*"]
+//	          include-operations: ["iam.ListUsers"]
+//	          explain: false
+func New(settings any) ([]*analysis.Analyzer, error) {
+	p, err := newPlugin(settings)
+	if err != nil {
+		return nil, err
+	}
+	return p.BuildAnalyzers()
+}
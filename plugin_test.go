@@ -0,0 +1,70 @@
+package awspagination_test
+
+import (
+	"testing"
+
+	"github.com/golangci/plugin-module-register/register"
+	_ "github.com/koh-sh/awspagination"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// TestPlugin verifies that the module plugin is registered and builds a configured analyzer,
+// as golangci-lint loads it from .custom-gcl.yml
+func TestPlugin(t *testing.T) {
+	newPlugin, err := register.GetPlugin("awspagination")
+	if err != nil {
+		t.Fatalf("GetPlugin() error = %v", err)
+	}
+
+	plugin, err := newPlugin(map[string]any{
+		"custom-fields": []any{"MyToken"},
+		"strict-loops":  true,
+	})
+	if err != nil {
+		t.Fatalf("creating plugin: %v", err)
+	}
+
+	if mode := plugin.GetLoadMode(); mode != register.LoadModeTypesInfo {
+		t.Errorf("GetLoadMode() = %q, want %q", mode, register.LoadModeTypesInfo)
+	}
+
+	analyzers, err := plugin.BuildAnalyzers()
+	if err != nil {
+		t.Fatalf("BuildAnalyzers() error = %v", err)
+	}
+	if len(analyzers) != 1 {
+		t.Fatalf("BuildAnalyzers() returned %d analyzers, want 1", len(analyzers))
+	}
+	if name := analyzers[0].Name; name != "awspagination" {
+		t.Errorf("analyzer name = %q, want %q", name, "awspagination")
+	}
+
+	// The settings are applied to the analyzer
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, analyzers[0], "test/strictloops")
+}
+
+// TestPluginInvalidSettings verifies that invalid settings are rejected when the plugin is created
+func TestPluginInvalidSettings(t *testing.T) {
+	newPlugin, err := register.GetPlugin("awspagination")
+	if err != nil {
+		t.Fatalf("GetPlugin() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		settings any
+	}{
+		{name: "unknown setting", settings: map[string]any{"strict-loop": true}},
+		{name: "wrong type", settings: map[string]any{"include-tests": "yes"}},
+		{name: "invalid limited-calls policy", settings: map[string]any{"limited-calls": "warn"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newPlugin(tt.settings); err == nil {
+				t.Errorf("creating plugin with %v: expected error", tt.settings)
+			}
+		})
+	}
+}
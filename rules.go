@@ -0,0 +1,54 @@
+package awspagination
+
+// Rule is a kind of finding of the analyzer. Its ID is the Category of the diagnostics
+// reporting such findings, so that drivers can group them or link to their documentation.
+type Rule struct {
+	// ID identifies the rule (e.g., "missing-pagination").
+	ID string
+
+	// Description explains what the rule reports.
+	Description string
+
+	// Informational is set for rules whose findings do not indicate a bug, such as calls
+	// intentionally limited to a single page.
+	Informational bool
+}
+
+// Rule IDs, used as the Category of the diagnostics of the analyzer.
+const (
+	ruleMissingPagination   = "missing-pagination"
+	ruleIncompleteIteration = "incomplete-iteration"
+	ruleIncompleteLoop      = "incomplete-loop"
+	ruleLimitedCall         = "limited-call"
+	ruleInvalidDirective    = "invalid-directive"
+	ruleUnusedDirective     = "unused-directive"
+)
+
+// Rules are the rules of the analyzer, in the order of their documentation.
+var Rules = []Rule{
+	{
+		ID:          ruleMissingPagination,
+		Description: "An AWS SDK List API call returns a pagination token, but only the first page of results is read.",
+	},
+	{
+		ID:          ruleIncompleteIteration,
+		Description: "A paginator (or an AWS SDK v1 Pages callback) is not iterated until the last page.",
+	},
+	{
+		ID:          ruleIncompleteLoop,
+		Description: "A manual pagination loop does not feed the token back into the input or does not stop on an empty token (-strict-loops).",
+	},
+	{
+		ID:            ruleLimitedCall,
+		Description:   "An AWS SDK List API call is intentionally limited to a single page by a page-size field (-limited-calls=info).",
+		Informational: true,
+	},
+	{
+		ID:          ruleInvalidDirective,
+		Description: "An " + ignoreDirective + " directive does not give a reason, so it suppresses nothing.",
+	},
+	{
+		ID:          ruleUnusedDirective,
+		Description: "An " + ignoreDirective + " directive does not suppress any diagnostic.",
+	},
+}
@@ -0,0 +1,159 @@
+package awspagination
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// AWS SDK for Go v1 has no paginator types. Instead, every paginated operation has
+// <Operation>Pages and <Operation>PagesWithContext methods on the service client, which
+// call a callback with each page until it returns false or the last page is read:
+//
+//	err := svc.ListUsersPages(input, func(page *iam.ListUsersOutput, lastPage bool) bool {
+//		users = append(users, page.Users...)
+//		return true
+//	})
+
+// isAWSSDKv1Package reports whether a package path is from AWS SDK for Go v1.
+func isAWSSDKv1Package(pkgPath string) bool {
+	return strings.Contains(pkgPath, "aws-sdk-go/service/")
+}
+
+// isAWSSDKv1Type reports whether t is a named type (or a pointer to one) declared in an
+// AWS SDK for Go v1 service package.
+func isAWSSDKv1Type(t types.Type) bool {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && isAWSSDKv1Package(named.Obj().Pkg().Path())
+}
+
+// pagesMethodName returns the name of the <Operation>Pages method paginating the
+// AWS SDK v1 method named methodName (e.g., ListUsersWithContext -> ListUsersPagesWithContext).
+func pagesMethodName(methodName string) string {
+	if operation, ok := strings.CutSuffix(methodName, "WithContext"); ok {
+		return operation + "PagesWithContext"
+	}
+	return methodName + "Pages"
+}
+
+// pagesMethodOf returns the service package and operation paginated by callExpr, if
+// callExpr calls an <Operation>Pages or <Operation>PagesWithContext method of an
+// AWS SDK v1 service client, or of its interface in the <service>iface package
+// (e.g., iamiface.IAMAPI). The service package is the one declaring the
+// <Operation>Input parameter, so that both resolve to the same package.
+func pagesMethodOf(pass *analysis.Pass, callExpr *ast.CallExpr) (*types.Package, string, bool) {
+	fn, ok := typeutil.Callee(pass.TypesInfo, callExpr).(*types.Func)
+	if !ok || fn.Pkg() == nil || !isAWSSDKv1Package(fn.Pkg().Path()) {
+		return nil, "", false
+	}
+	sig := fn.Type().(*types.Signature)
+	if sig.Recv() == nil {
+		return nil, "", false
+	}
+
+	name := strings.TrimSuffix(fn.Name(), "WithContext")
+	operation, ok := strings.CutSuffix(name, "Pages")
+	if !ok || operation == "" {
+		return nil, "", false
+	}
+	pkg := operationInputPackage(sig, operation)
+	if pkg == nil {
+		return nil, "", false
+	}
+	return pkg, operation, true
+}
+
+// operationInputPackage returns the AWS SDK v1 service package declaring the
+// <Operation>Input type of a parameter of sig, or nil if there is none.
+func operationInputPackage(sig *types.Signature, operation string) *types.Package {
+	for param := range sig.Params().Variables() {
+		t := param.Type()
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		named, ok := t.(*types.Named)
+		if ok && isAWSSDKv1Type(named) && named.Obj().Name() == operation+"Input" {
+			return named.Obj().Pkg()
+		}
+	}
+	return nil
+}
+
+// operationPagesMethod returns the <Operation>Pages method declared on a client type of
+// the AWS SDK v1 service package pkg, or nil if the operation does not paginate.
+func operationPagesMethod(pkg *types.Package, operation string) *types.Func {
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		typeName, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok {
+			continue
+		}
+		obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(typeName.Type()), false, pkg, operation+"Pages")
+		if method, ok := obj.(*types.Func); ok {
+			return method
+		}
+	}
+	return nil
+}
+
+// pagesCallback returns the callback passed to the <Operation>Pages method called by
+// callExpr, or nil if it is not a function literal.
+func pagesCallback(callExpr *ast.CallExpr) *ast.FuncLit {
+	for _, arg := range callExpr.Args {
+		if fn, ok := ast.Unparen(arg).(*ast.FuncLit); ok {
+			return fn
+		}
+	}
+	return nil
+}
+
+// checkPagesCallback reports callbacks of AWS SDK v1 <Operation>Pages methods that
+// unconditionally return false, which stops the iteration after the first page.
+// Like unconditionalExit for paginator loops, only return statements at the top level
+// of the callback body are considered.
+// The call sites of <Operation>Pages methods are recorded in inv.
+func checkPagesCallback(pass *analysis.Pass, inv *inventory, callExpr *ast.CallExpr) {
+	pkg, operation, ok := pagesMethodOf(pass, callExpr)
+	if !ok {
+		return
+	}
+	site := inv.recordIteration(pass, callExpr, pkg, operation, "")
+	callback := pagesCallback(callExpr)
+	if callback == nil {
+		return
+	}
+	inv.check(site, func() { checkPagesCallbackReturns(pass, callback, operation) })
+}
+
+// checkPagesCallbackReturns reports the callback of an <Operation>Pages method if it returns false
+// unconditionally, see checkPagesCallback.
+func checkPagesCallbackReturns(pass *analysis.Pass, callback *ast.FuncLit, operation string) {
+	for _, stmt := range callback.Body.List {
+		ret, ok := stmt.(*ast.ReturnStmt)
+		if !ok {
+			continue
+		}
+		if len(ret.Results) == 1 && isFalse(pass, ret.Results[0]) {
+			report(pass, analysis.Diagnostic{
+				Pos:      ret.Pos(),
+				Category: ruleIncompleteIteration,
+				Message: "incomplete paginator iteration: callback of " + operation +
+					"Pages returns false unconditionally, so only the first page is read",
+			})
+		}
+		return
+	}
+}
+
+// isFalse reports whether expr is the constant false.
+func isFalse(pass *analysis.Pass, expr ast.Expr) bool {
+	tv, ok := pass.TypesInfo.Types[expr]
+	return ok && tv.Value != nil && tv.Value.Kind() == constant.Bool && !constant.BoolVal(tv.Value)
+}
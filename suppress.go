@@ -0,0 +1,77 @@
+package awspagination
+
+import (
+	"go/ast"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// suppressionComment is the directive inserted by the suppression fix, with a placeholder
+// for the reason why only the first page is needed.
+const suppressionComment = ignoreDirective + " TODO: explain why only the first page is needed"
+
+// report reports the diagnostic along with a suggested fix that suppresses it.
+// All diagnostics of the analyzer are reported through report, so that editors and
+// golangci-lint --fix can suppress any of them in one step.
+// The directive applies to the statement following it, see parseIgnoreDirectives.
+func report(pass *analysis.Pass, diagnostic analysis.Diagnostic) {
+	if fix := suppressionFix(pass, diagnostic.Pos); fix != nil {
+		diagnostic.SuggestedFixes = append(diagnostic.SuggestedFixes, *fix)
+	}
+	pass.Report(diagnostic)
+}
+
+// suppressionFix returns a suggested fix inserting suppressionComment on its own line
+// above the statement (or declaration) enclosing pos, with the indentation of that statement.
+// Returns nil if pos is not inside a statement or declaration.
+func suppressionFix(pass *analysis.Pass, pos token.Pos) *analysis.SuggestedFix {
+	file := fileOf(pass, pos)
+	if file == nil {
+		return nil
+	}
+
+	// Package-level declarations are suppressed as a whole
+	path, _ := astutil.PathEnclosingInterval(file, pos, pos)
+	var stmt ast.Node
+	for _, node := range path {
+		switch node.(type) {
+		case ast.Stmt, ast.Decl:
+			stmt = node
+		}
+		if stmt != nil {
+			break
+		}
+	}
+	if stmt == nil {
+		return nil
+	}
+
+	tokFile := pass.Fset.File(stmt.Pos())
+	content, err := pass.ReadFile(tokFile.Name())
+	if err != nil {
+		return nil
+	}
+	lineStart := tokFile.LineStart(tokFile.Line(stmt.Pos()))
+	indent := lineIndent(content, tokFile.Offset(stmt.Pos()))
+
+	return &analysis.SuggestedFix{
+		Message: "Suppress with " + ignoreDirective,
+		TextEdits: []analysis.TextEdit{{
+			Pos:     lineStart,
+			End:     lineStart,
+			NewText: []byte(indent + suppressionComment + "\n"),
+		}},
+	}
+}
+
+// fileOf returns the file of the package containing pos, or nil if there is none.
+func fileOf(pass *analysis.Pass, pos token.Pos) *ast.File {
+	for _, file := range pass.Files {
+		if file.FileStart <= pos && pos < file.FileEnd {
+			return file
+		}
+	}
+	return nil
+}
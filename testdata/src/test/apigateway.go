@@ -26,12 +26,12 @@ func badAPIGatewayGetResources() {
 	_ = result.Items
 }
 
-// Bad: No pagination handling for GetAuthorizers
-func badAPIGatewayGetAuthorizers() {
+// Good: GetAuthorizers has no paginator, so its Position field is not a pagination token
+func goodAPIGatewayGetAuthorizersWithoutPaginator() {
 	client := &apigateway.Client{}
 	ctx := context.Background()
 	input := &apigateway.GetAuthorizersInput{}
-	result, _ := client.GetAuthorizers(ctx, input) // want "missing pagination handling for AWS SDK List API call"
+	result, _ := client.GetAuthorizers(ctx, input)
 	_ = result.Items
 }
 
@@ -5,6 +5,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 )
 
@@ -115,29 +116,29 @@ func good5() {
 
 // Test cases for other pagination token fields
 
-// Bad: NextMarker not handled (S3 ListObjects)
+// Bad: NextMarker not handled (Route53 ListHostedZones, paginated along with IsTruncated)
 func badNextMarker() {
-	client := &s3.Client{}
+	client := &route53.Client{}
 	ctx := context.Background()
-	input := &s3.ListObjectsInput{}
-	result, _ := client.ListObjects(ctx, input) // want "missing pagination handling for AWS SDK List API call"
+	input := &route53.ListHostedZonesInput{}
+	result, _ := client.ListHostedZones(ctx, input) // want "missing pagination handling for AWS SDK List API call"
 	_ = result
 }
 
 // Good: NextMarker handled with manual loop
 func goodNextMarker() {
-	client := &s3.Client{}
+	client := &route53.Client{}
 	ctx := context.Background()
-	input := &s3.ListObjectsInput{}
+	input := &route53.ListHostedZonesInput{}
 	for {
-		result, err := client.ListObjects(ctx, input)
+		result, err := client.ListHostedZones(ctx, input)
 		if err != nil {
 			break
 		}
-		for _, item := range result.Contents {
+		for _, item := range result.HostedZones {
 			_ = item
 		}
-		if result.NextMarker == nil {
+		if !result.IsTruncated || result.NextMarker == nil {
 			break
 		}
 		input.Marker = result.NextMarker
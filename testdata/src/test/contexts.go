@@ -0,0 +1,133 @@
+package test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+)
+
+// Test cases for List API calls outside of assignment statements
+// Each context has its own rule depending on how the result is consumed
+
+// Bad: Variable declaration without pagination handling
+func badVarDecl() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	var result, err = client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	_, _ = result, err
+}
+
+// Good: Variable declaration with pagination handling
+func goodVarDecl() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	var result, _ = client.ListTasks(ctx, &ecs.ListTasksInput{})
+	_ = result.NextToken
+}
+
+// returnTasks returns the List API result directly, moving the obligation to its callers
+func returnTasks(client *ecs.Client) (*ecs.ListTasksOutput, error) { // want returnTasks:"paginationObligation\\[0\\]"
+	return client.ListTasks(context.Background(), &ecs.ListTasksInput{})
+}
+
+// mustListTasks returns the List API result as its only result
+func mustListTasks(client *ecs.Client) *ecs.ListTasksOutput { // want mustListTasks:"paginationObligation\\[0\\]"
+	result, err := client.ListTasks(context.Background(), &ecs.ListTasksInput{})
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// Bad: Caller of a function directly returning the List API result
+func badReturnCaller() {
+	client := &ecs.Client{}
+	result, _ := returnTasks(client) // want "Use NewListTasksPaginator"
+	_ = result
+}
+
+// Good: Result returned from a function literal is checked where the literal is called
+func goodFuncLitReturn() {
+	client := &ecs.Client{}
+	list := func() (*ecs.ListTasksOutput, error) {
+		return client.ListTasks(context.Background(), &ecs.ListTasksInput{})
+	}
+	result, _ := list()
+	_ = result.NextToken
+}
+
+// processTasks consumes the tasks without paginating
+func processTasks(out *ecs.ListTasksOutput, err error) {
+	_ = out.TaskArns
+}
+
+// followTasks reads the pagination token of its first parameter
+func followTasks(out *ecs.ListTasksOutput, err error) { // want followTasks:"handlesPagination\\[0\\]"
+	_ = out.NextToken
+}
+
+// Bad: Result passed as argument to a function that does not handle pagination
+func badArgument() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	processTasks(client.ListTasks(ctx, &ecs.ListTasksInput{})) // want "missing pagination handling for AWS SDK List API call"
+}
+
+// Good: Result passed as argument to a function that handles pagination
+func goodArgument() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	followTasks(client.ListTasks(ctx, &ecs.ListTasksInput{}))
+}
+
+// Bad: Single-valued result passed as argument to a function that does not handle pagination
+func badSingleArgument() {
+	client := &ecs.Client{}
+	printTasks(mustListTasks(client)) // want "missing pagination handling for AWS SDK List API call"
+}
+
+// Good: Single-valued result passed as argument to a function that handles pagination
+func goodSingleArgument() {
+	client := &ecs.Client{}
+	drainTasks(mustListTasks(client), client)
+}
+
+type taskHolder struct {
+	out *ecs.ListTasksOutput
+}
+
+// Bad: Result stored in a composite literal field
+func badCompositeLiteral() {
+	client := &ecs.Client{}
+	holder := taskHolder{out: mustListTasks(client)} // want "missing pagination handling for AWS SDK List API call"
+	_ = holder
+}
+
+// Bad: Result stored in a struct field
+func badFieldStore(holder *taskHolder) {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	holder.out, _ = client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+}
+
+// Bad: Result stored in a map element
+func badIndexStore(outputs map[string]*ecs.ListTasksOutput) {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	outputs["default"], _ = client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+}
+
+// Bad: Result consumed in place
+func badSelector() {
+	client := &ecs.Client{}
+	for _, arn := range mustListTasks(client).TaskArns { // want "missing pagination handling for AWS SDK List API call"
+		_ = arn
+	}
+}
+
+// Good: Result discarded in an expression statement
+func goodDiscarded() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	client.ListTasks(ctx, &ecs.ListTasksInput{})
+}
@@ -0,0 +1,80 @@
+package test
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+)
+
+// Test cases for //awspagination:ignore directives
+
+// Good: Directive at the end of the line
+func goodIgnoreTrailing() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{}) //awspagination:ignore only the first page is needed
+	log.Println(result.TaskArns)
+}
+
+// Good: Directive on its own line, covering the whole statement below it
+func goodIgnoreStatement() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	//awspagination:ignore only the first page is needed
+	log.Println(
+		client.ListTasks(ctx, &ecs.ListTasksInput{}),
+	)
+}
+
+// Good: Directive in the doc comment, covering the whole function
+//
+//awspagination:ignore the number of tasks is bounded
+func goodIgnoreFunction() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	first, _ := client.ListTasks(ctx, &ecs.ListTasksInput{})
+	log.Println(first.TaskArns)
+	second, _ := client.ListTasks(ctx, &ecs.ListTasksInput{})
+	log.Println(second.TaskArns)
+}
+
+// Bad: Directive on its own line only covers the statement below it
+func badIgnoreNextStatementOnly() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	//awspagination:ignore only the first page is needed
+	first, _ := client.ListTasks(ctx, &ecs.ListTasksInput{})
+	log.Println(first.TaskArns)
+	second, _ := client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	log.Println(second.TaskArns)
+}
+
+// Bad: Directive without a reason suppresses nothing
+func badIgnoreWithoutReason() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{}) /* want "missing pagination handling for AWS SDK List API call" "//awspagination:ignore directive requires a reason" */ //awspagination:ignore
+	log.Println(result.TaskArns)
+}
+
+// Bad: Directive left over from code that has been fixed
+func badIgnoreUnused() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	//awspagination:ignore only the first page is needed // want "unused //awspagination:ignore directive: no diagnostic is suppressed"
+	paginator := ecs.NewListTasksPaginator(client, &ecs.ListTasksInput{})
+	for paginator.HasMorePages() {
+		page, _ := paginator.NextPage(ctx)
+		log.Println(page.TaskArns)
+	}
+}
+
+// Good: Directive on a manual loop, which only has findings with -strict-loops or
+// -strict-token-use, is not reported as unused without these flags
+func goodIgnoreStrictOnly() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{}) //awspagination:ignore the next token is only logged
+	log.Println(result.TaskArns, result.NextToken)
+}
@@ -0,0 +1,20 @@
+//awspagination:ignore this file only inspects the first page of results
+
+package test
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+)
+
+// Test cases for file-level //awspagination:ignore directives
+
+// Good: Suppressed by the directive at the top of the file
+func goodIgnoreFile() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{})
+	log.Println(result.TaskArns)
+}
@@ -0,0 +1,17 @@
+package exclude
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+)
+
+// Test cases for file exclusion configured with
+// -exclude-paths=internal/mocks/**,**/zz_generated*.go
+
+// Bad: Files not matching a pattern are still analyzed
+func listTasks(ctx context.Context, client *ecs.Client) {
+	result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	log.Println(result.TaskArns)
+}
@@ -0,0 +1,14 @@
+package mocks
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+)
+
+// Good: Excluded by internal/mocks/**
+func ListTasks(ctx context.Context, client *ecs.Client) {
+	result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{})
+	log.Println(result.TaskArns)
+}
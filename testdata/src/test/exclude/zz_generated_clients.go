@@ -0,0 +1,14 @@
+package exclude
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+)
+
+// Good: Excluded by **/zz_generated*.go
+func listServices(ctx context.Context, client *ecs.Client) {
+	result, _ := client.ListServices(ctx, &ecs.ListServicesInput{})
+	log.Println(result.ServiceArns)
+}
@@ -0,0 +1,99 @@
+package explain
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+)
+
+// Test cases for the decision traces of -explain
+
+type page struct {
+	Items     []string
+	NextToken *string
+}
+
+// Not reported: A paginator of the same operation from the same client counts as handling
+func paginatorOfSameClient(ctx context.Context, client *ecs.Client) {
+	out, _ := client.ListTasks(ctx, &ecs.ListTasksInput{})
+	log.Println(out.TaskArns)
+
+	paginator := ecs.NewListTasksPaginator(client, &ecs.ListTasksInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return
+		}
+		log.Println(page.TaskArns)
+	}
+}
+
+// Not reported: The token is read
+func tokenRead(ctx context.Context, client *ecs.Client) {
+	services, _ := client.ListServices(ctx, &ecs.ListServicesInput{})
+	log.Println(services.NextToken)
+}
+
+// Reported: No handling
+func unhandled(ctx context.Context, client *ecs.Client) {
+	clusters, _ := client.ListClusters(ctx, &ecs.ListClustersInput{}) // want "missing pagination handling"
+	log.Println(clusters.ClusterArns)
+}
+
+// Not checked: Not an AWS SDK type
+func notAWS() {
+	p, _ := fetch()
+	log.Println(p.Items)
+}
+
+func fetch() (*page, error) {
+	return &page{}, nil
+}
+
+// Not reported here: The result is returned
+func returned(ctx context.Context, client *ecs.Client) (*ecs.ListTasksOutput, error) { // want returned:"paginationObligation\\[0\\]"
+	out, err := client.ListTasks(ctx, &ecs.ListTasksInput{})
+	return out, err
+}
+
+// Not reported here: The call is returned directly
+func returnedDirectly(ctx context.Context, client *ecs.Client) (*ecs.ListServicesOutput, error) { // want returnedDirectly:"paginationObligation\\[0\\]"
+	return client.ListServices(ctx, &ecs.ListServicesInput{})
+}
+
+// Not reported: The result is passed to a helper handling its pagination
+func passedToHelper(ctx context.Context, client *ecs.Client) {
+	handle(client.ListContainerInstances(ctx, &ecs.ListContainerInstancesInput{}))
+}
+
+func handle(out *ecs.ListContainerInstancesOutput, err error) { // want handle:"handlesPagination\\[0\\]"
+	if err == nil && out.NextToken != nil {
+		log.Println("more pages")
+	}
+}
+
+// Reported: The result is passed to a function not handling its pagination
+func passedToLog(ctx context.Context, client *ecs.Client) {
+	log.Println(client.ListContainerInstances(ctx, &ecs.ListContainerInstancesInput{})) // want "missing pagination handling"
+}
+
+// Reported: The result is consumed in place
+func consumedInPlace(ctx context.Context, client *ecs.Client) []*ecs.ListTaskDefinitionsOutput {
+	return []*ecs.ListTaskDefinitionsOutput{firstTaskDefinitions(ctx, client)} // want "missing pagination handling"
+}
+
+func firstTaskDefinitions(ctx context.Context, client *ecs.Client) *ecs.ListTaskDefinitionsOutput { // want firstTaskDefinitions:"paginationObligation\\[0\\]"
+	out, _ := client.ListTaskDefinitions(ctx, &ecs.ListTaskDefinitionsInput{})
+	return out
+}
+
+// Not reported: The result is discarded
+func discarded(ctx context.Context, client *ecs.Client) {
+	client.ListTaskDefinitionFamilies(ctx, &ecs.ListTaskDefinitionFamiliesInput{})
+}
+
+// Not reported: The operation is excluded
+func excluded(ctx context.Context, client *ecs.Client) {
+	defer client.ListAccountSettings(ctx, &ecs.ListAccountSettingsInput{})
+}
@@ -0,0 +1,50 @@
+package fingerprint
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+)
+
+// Test cases for the fingerprints of findings
+
+type lister struct {
+	client *ecs.Client
+}
+
+// Method: The function is qualified by the receiver type
+func (l *lister) listTasks(ctx context.Context) {
+	tasks, _ := l.client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	log.Println(tasks.TaskArns)
+}
+
+// Function literal: The enclosing declaration is used
+func listServices(ctx context.Context, client *ecs.Client) {
+	func() {
+		services, _ := client.ListServices(ctx, &ecs.ListServicesInput{}) // want "missing pagination handling for AWS SDK List API call"
+		log.Println(services.ServiceArns)
+	}()
+}
+
+// Not assigned: No variable
+func listClusters(ctx context.Context, client *ecs.Client) {
+	log.Println(client.ListClusters(ctx, &ecs.ListClustersInput{})) // want "missing pagination handling for AWS SDK List API call"
+}
+
+// Paginator: The operation of the page type
+func firstPage(ctx context.Context, client *ecs.Client) {
+	paginator := ecs.NewListTasksPaginator(client, &ecs.ListTasksInput{})
+	page, _ := paginator.NextPage(ctx) // want "incomplete paginator iteration"
+	log.Println(page.TaskArns)
+}
+
+// Directive: Unused directives are findings too
+func listAllTasks(ctx context.Context, client *ecs.Client) {
+	//awspagination:ignore only the first page is needed // want "unused //awspagination:ignore directive"
+	paginator := ecs.NewListTasksPaginator(client, &ecs.ListTasksInput{})
+	for paginator.HasMorePages() {
+		page, _ := paginator.NextPage(ctx)
+		log.Println(page.TaskArns)
+	}
+}
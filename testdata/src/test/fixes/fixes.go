@@ -0,0 +1,114 @@
+package fixes
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// Test cases for the suggested fix rewriting a List API call into a paginator loop
+
+// Fixed: Slice field read after the error check
+func listTaskArns(ctx context.Context, client *ecs.Client, in *ecs.ListTasksInput) ([]string, error) {
+	out, err := client.ListTasks(ctx, in) // want "missing pagination handling for AWS SDK List API call"
+	if err != nil {
+		return nil, fmt.Errorf("listing tasks: %w", err)
+	}
+	log.Printf("found %d tasks", len(out.TaskArns))
+	return out.TaskArns, nil
+}
+
+// Fixed: Element type from an imported types package, nested in a block
+func listObjects(ctx context.Context, client *s3.Client, verbose bool) []types.Object {
+	if verbose {
+		result, err := client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{Bucket: nil}) // want "missing pagination handling for AWS SDK List API call"
+		if err != nil {
+			log.Println(err)
+			return nil
+		}
+		return result.Contents
+	}
+	return nil
+}
+
+// Not fixed: The error check does not leave the function
+func listTasksLogOnly(ctx context.Context, client *ecs.Client) []string {
+	out, err := client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	if err != nil {
+		log.Println(err)
+	}
+	return out.TaskArns
+}
+
+// Not fixed: The result is used as a whole
+func listTasksWhole(ctx context.Context, client *ecs.Client) *ecs.ListTasksOutput {
+	out, err := client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	if err != nil {
+		return nil
+	}
+	log.Println(out.TaskArns)
+	logOutput(out)
+	return nil
+}
+
+func logOutput(out *ecs.ListTasksOutput) {
+	log.Println(out.TaskArns)
+}
+
+// Not fixed: The accumulator name is already taken
+func listTasksNameTaken(ctx context.Context, client *ecs.Client) int {
+	taskArns := 0
+	out, err := client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	if err != nil {
+		return 0
+	}
+	return taskArns + len(out.TaskArns)
+}
+
+// Not fixed: The error is used after the check
+func listTasksErrUsed(ctx context.Context, client *ecs.Client) error {
+	out, err := client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	if err != nil {
+		return err
+	}
+	log.Println(out.TaskArns)
+	return err
+}
+
+// Not fixed: Incomplete paginator iterations are only suppressed
+func listTasksFirstPage(ctx context.Context, client *ecs.Client) []string {
+	paginator := ecs.NewListTasksPaginator(client, &ecs.ListTasksInput{})
+	page, err := paginator.NextPage(ctx) // want "incomplete paginator iteration"
+	if err != nil {
+		return nil
+	}
+	return page.TaskArns
+}
+
+// Fixed: Raw string literals in the error check keep their content
+func listTasksRawString(ctx context.Context, client *ecs.Client) []string {
+	out, err := client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	if err != nil {
+		log.Println(`listing tasks failed:
+	see the logs`, err)
+		return nil
+	}
+	return out.TaskArns
+}
+
+// Not fixed: The accumulator name is declared in a nested scope
+func listTasksNameInNestedScope(ctx context.Context, client *ecs.Client, verbose bool) []string {
+	out, err := client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	if err != nil {
+		return nil
+	}
+	if verbose {
+		taskArns := 0
+		log.Println(taskArns, len(out.TaskArns))
+	}
+	return out.TaskArns
+}
@@ -0,0 +1,112 @@
+package test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+)
+
+// Test cases for matching token accesses by variable identity rather than by name
+// Only reads of the value returned by the flagged call count as pagination handling
+
+// Bad: Shadowed variable in an inner block reads NextToken
+func badShadowed() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	_ = result
+	if result != nil {
+		result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{})
+		_ = result.NextToken
+	}
+}
+
+// Bad: Unrelated variable with the same name reads NextToken
+func badSameNameElsewhere() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	{
+		result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+		_ = result
+	}
+	{
+		result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{})
+		_ = result.NextToken
+	}
+}
+
+// Good: Alias of the result reads NextToken
+func goodAlias() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{})
+	r := result
+	_ = r.NextToken
+}
+
+// Good: Alias of an alias reads NextToken
+func goodAliasChain() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{})
+	r := result
+	var page *ecs.ListTasksOutput
+	page = r
+	_ = page.NextToken
+}
+
+// Good: Alias of the result passed to a helper that handles pagination
+func goodAliasHelper() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{})
+	r := result
+	drainTasks(r, client)
+}
+
+// Bad: NextToken is only read after the variable is reassigned with another call
+func badReassigned() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	_ = result.TaskArns
+	result, _ = client.ListTasks(ctx, &ecs.ListTasksInput{})
+	_ = result.NextToken
+}
+
+// Good: NextToken read after a reassignment in a branch that may not be taken
+func goodBranchReassigned(refresh bool) {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{})
+	if refresh {
+		result = &ecs.ListTasksOutput{}
+	}
+	_ = result.NextToken
+}
+
+// Bad: NextToken only read in the branch after its reassignment
+func badBranchReassigned(refresh bool) {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	_ = result.TaskArns
+	if refresh {
+		result = &ecs.ListTasksOutput{}
+		_ = result.NextToken
+	}
+}
+
+// Good: NextToken read in the loop condition before the call
+func goodLoopCarried() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	input := &ecs.ListTasksInput{}
+	var result *ecs.ListTasksOutput
+	for result == nil || result.NextToken != nil {
+		if result != nil {
+			input.NextToken = result.NextToken
+		}
+		result, _ = client.ListTasks(ctx, input)
+	}
+}
@@ -0,0 +1,86 @@
+package test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+
+	"test/pagehelper"
+)
+
+// Test cases for pagination handled by helper functions
+// Service-layer code often hands the List API result to a helper that follows the token
+
+// drainTasks reads the pagination token of its first parameter
+func drainTasks(out *ecs.ListTasksOutput, client *ecs.Client) { // want drainTasks:"handlesPagination\\[0\\]"
+	if out.NextToken != nil {
+		_ = out.NextToken
+	}
+}
+
+// forwardTasks delegates pagination handling to drainTasks
+func forwardTasks(client *ecs.Client, out *ecs.ListTasksOutput) { // want forwardTasks:"handlesPagination\\[1\\]"
+	drainTasks(out, client)
+}
+
+// printTasks consumes the result without paginating
+func printTasks(out *ecs.ListTasksOutput) {
+	for _, task := range out.TaskArns {
+		_ = task
+	}
+}
+
+// Good: Result passed to a helper that reads NextToken
+func goodHelper() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{})
+	drainTasks(result, client)
+}
+
+// Good: Result passed to a helper that delegates to another helper
+func goodNestedHelper() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{})
+	forwardTasks(client, result)
+}
+
+// Good: Result passed to a helper declared in another package
+func goodCrossPackageHelper() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{})
+	_ = pagehelper.NextTaskToken(result)
+}
+
+// Bad: Helper does not handle pagination
+func badHelper() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	printTasks(result)
+}
+
+// Bad: Helper in another package does not handle pagination
+func badCrossPackageHelper() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	pagehelper.PrintTasks(result)
+}
+
+// Bad: Result passed in a parameter position that the helper does not handle
+func badHelperWrongParam() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	handled, _ := client.ListTasks(ctx, &ecs.ListTasksInput{})
+	compareTasks(handled, result)
+}
+
+// compareTasks only handles pagination of its first parameter
+func compareTasks(a, b *ecs.ListTasksOutput) { // want compareTasks:"handlesPagination\\[0\\]"
+	_ = a.NextToken
+	_ = b.TaskArns
+}
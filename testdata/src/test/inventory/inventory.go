@@ -0,0 +1,112 @@
+package inventory
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	iamv1 "github.com/aws/aws-sdk-go/service/iam"
+)
+
+// Test cases for the call sites recorded in the result, one per status
+
+type store struct {
+	out *ecs.ListTasksOutput
+}
+
+// Paginator: The constructor is recorded
+func paginator(ctx context.Context, client *ecs.Client) {
+	tasks := ecs.NewListTasksPaginator(client, &ecs.ListTasksInput{})
+	for tasks.HasMorePages() {
+		page, err := tasks.NextPage(ctx)
+		if err != nil {
+			return
+		}
+		log.Println(page.TaskArns)
+	}
+}
+
+// Manual loop: The token is read
+func manualLoop(ctx context.Context, client *ecs.Client) {
+	input := &ecs.ListServicesInput{}
+	for {
+		services, err := client.ListServices(ctx, input)
+		if err != nil {
+			return
+		}
+		log.Println(services.ServiceArns)
+		if services.NextToken == nil {
+			break
+		}
+		input.NextToken = services.NextToken
+	}
+}
+
+// Helper: The result is passed to a function handling its pagination
+func helper(ctx context.Context, client *ecs.Client) {
+	instances, _ := client.ListContainerInstances(ctx, &ecs.ListContainerInstancesInput{})
+	handle(instances)
+}
+
+func handle(out *ecs.ListContainerInstancesOutput) { // want handle:"handlesPagination\\[0\\]"
+	if out.NextToken != nil {
+		log.Println("more pages")
+	}
+}
+
+// Returned: The callers handle pagination
+func returned(ctx context.Context, client *s3.Client) (*s3.ListObjectsV2Output, error) { // want returned:"paginationObligation\\[0\\]"
+	return client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{})
+}
+
+// Limited: A single page is read on purpose
+func limited(ctx context.Context, client *ecs.Client) {
+	first, _ := client.ListTaskDefinitions(ctx, &ecs.ListTaskDefinitionsInput{MaxResults: aws.Int32(1)})
+	log.Println(first.TaskDefinitionArns)
+}
+
+// Excluded: The operation is excluded
+func excluded(ctx context.Context, client *ecs.Client) {
+	clusters, _ := client.ListClusters(ctx, &ecs.ListClustersInput{})
+	log.Println(clusters.ClusterArns)
+}
+
+// Discarded: The result is not used
+func discarded(ctx context.Context, client *ecs.Client) {
+	_, _ = client.ListTaskDefinitionFamilies(ctx, &ecs.ListTaskDefinitionFamiliesInput{})
+}
+
+// Unhandled: The result is stored in a field
+func (s *store) stored(ctx context.Context, client *ecs.Client) {
+	s.out, _ = client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "missing pagination handling"
+}
+
+// Suppressed: The finding is suppressed with a reason
+func suppressed(ctx context.Context, client *ecs.Client) {
+	//awspagination:ignore only the first services are shown
+	services, _ := client.ListServices(ctx, &ecs.ListServicesInput{})
+	log.Println(services.ServiceArns)
+}
+
+// Unhandled: The finding is reported
+func unhandled(ctx context.Context, client *ecs.Client) {
+	tasks, _ := client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "missing pagination handling"
+	log.Println(tasks.TaskArns)
+}
+
+// Unhandled: The paginator is not iterated in a loop
+func firstPage(ctx context.Context, client *ecs.Client) {
+	tasks := ecs.NewListTasksPaginator(client, &ecs.ListTasksInput{})
+	page, _ := tasks.NextPage(ctx) // want "incomplete paginator iteration"
+	log.Println(page.TaskArns)
+}
+
+// Paginator: The v1 Pages method is recorded
+func pages(svc *iamv1.IAM) error {
+	return svc.ListUsersPages(&iamv1.ListUsersInput{}, func(page *iamv1.ListUsersOutput, lastPage bool) bool {
+		log.Println(page.Users)
+		return true
+	})
+}
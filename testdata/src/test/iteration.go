@@ -0,0 +1,127 @@
+package test
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+)
+
+// Test cases for checking that paginators are fully iterated
+
+// Good: Loop conditioned on HasMorePages with a conditional exit
+func goodPaginatorConditionalExit() error {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	paginator := ecs.NewListTasksPaginator(client, &ecs.ListTasksInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		log.Println(page.TaskArns)
+	}
+	return nil
+}
+
+// Good: Loop leaving on HasMorePages in its body
+func goodPaginatorBreakOnHasMorePages() error {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	p := ecs.NewListTasksPaginator(client, &ecs.ListTasksInput{})
+	for {
+		if !p.HasMorePages() {
+			break
+		}
+		page, err := p.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		log.Println(page.TaskArns)
+	}
+	return nil
+}
+
+// Good: Paginator iterated by another function
+func goodPaginatorPassedOn() {
+	client := &ecs.Client{}
+	paginator := ecs.NewListTasksPaginator(client, &ecs.ListTasksInput{})
+	drainPaginator(paginator)
+}
+
+func drainPaginator(paginator *ecs.ListTasksPaginator) {
+	for paginator.HasMorePages() {
+		_, _ = paginator.NextPage(context.Background())
+	}
+}
+
+// Good: Paginator returned to the caller
+func goodPaginatorReturned(client *ecs.Client) *ecs.ListTasksPaginator {
+	paginator := ecs.NewListTasksPaginator(client, &ecs.ListTasksInput{})
+	return paginator
+}
+
+// Bad: Paginator created but never iterated
+func badPaginatorNeverIterated() {
+	client := &ecs.Client{}
+	paginator := ecs.NewListTasksPaginator(client, &ecs.ListTasksInput{}) // want "incomplete paginator iteration: paginator paginator is created but never iterated"
+	_ = paginator.HasMorePages()
+}
+
+// Bad: Only the first page is read
+func badPaginatorIteratedOnce() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	p := ecs.NewListTasksPaginator(client, &ecs.ListTasksInput{})
+	page, _ := p.NextPage(ctx) // want "incomplete paginator iteration: p.NextPage is not called in a loop conditioned on p.HasMorePages"
+	log.Println(page.TaskArns)
+}
+
+// Bad: Loop not conditioned on HasMorePages
+func badPaginatorBoundedLoop() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	p := ecs.NewListTasksPaginator(client, &ecs.ListTasksInput{})
+	for i := 0; i < 3; i++ {
+		page, _ := p.NextPage(ctx) // want "incomplete paginator iteration: p.NextPage is not called in a loop conditioned on p.HasMorePages"
+		log.Println(page.TaskArns)
+	}
+}
+
+// Bad: HasMorePages checked in the body without leaving the loop
+func badPaginatorHasMorePagesNoExit() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	p := ecs.NewListTasksPaginator(client, &ecs.ListTasksInput{})
+	for {
+		if !p.HasMorePages() {
+			log.Println("no more pages")
+		}
+		page, _ := p.NextPage(ctx) // want "incomplete paginator iteration: p.NextPage is not called in a loop conditioned on p.HasMorePages"
+		log.Println(page.TaskArns)
+	}
+}
+
+// Bad: Loop exits unconditionally in the first iteration
+func badPaginatorUnconditionalBreak() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	p := ecs.NewListTasksPaginator(client, &ecs.ListTasksInput{})
+	for p.HasMorePages() {
+		page, _ := p.NextPage(ctx)
+		log.Println(page.TaskArns)
+		break // want "incomplete paginator iteration: loop over p exits unconditionally in the first iteration"
+	}
+}
+
+// Bad: Loop returns unconditionally in the first iteration
+func badPaginatorUnconditionalReturn() []string {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	p := ecs.NewListTasksPaginator(client, &ecs.ListTasksInput{})
+	for p.HasMorePages() {
+		page, _ := p.NextPage(ctx)
+		return page.TaskArns // want "incomplete paginator iteration: loop over p exits unconditionally in the first iteration"
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package limited
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// Test cases for calls intentionally limited to a single page (-limited-calls=info)
+
+// Info: Page size set in the input literal
+func limitedInPlace(ctx context.Context, client *ecs.Client) {
+	result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{MaxResults: aws.Int32(10)}) // want "info: ListTasks is limited to a single page by MaxResults, so further pages are intentionally not read"
+	log.Println(result.TaskArns)
+}
+
+// Info: Page size set in the literal assigned to the input variable
+func limitedInputVariable(ctx context.Context, client *s3.Client) {
+	input := &s3.ListObjectsV2Input{
+		Bucket:  aws.String("bucket"),
+		MaxKeys: aws.Int32(1),
+	}
+	result, _ := client.ListObjectsV2(ctx, input) // want "info: ListObjectsV2 is limited to a single page by MaxKeys"
+	log.Println(len(result.Contents) > 0)
+}
+
+// Info: Limit of DynamoDB
+func limitedDynamoDB(ctx context.Context, client *dynamodb.Client) {
+	result, _ := client.Scan(ctx, &dynamodb.ScanInput{TableName: aws.String("table"), Limit: aws.Int32(1)}) // want "info: Scan is limited to a single page by Limit"
+	log.Println(result.Items)
+}
+
+// Bad: No page size is set
+func notLimited(ctx context.Context, client *ecs.Client) {
+	result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	log.Println(result.TaskArns)
+}
+
+// Bad: The input is not a literal
+func notLimitedInputParam(ctx context.Context, client *ecs.Client, input *ecs.ListTasksInput) {
+	result, _ := client.ListTasks(ctx, input) // want "missing pagination handling for AWS SDK List API call"
+	log.Println(result.TaskArns)
+}
+
+// Bad: The result is returned to the caller, which may expect all pages
+func notLimitedReturned(ctx context.Context, client *ecs.Client) *ecs.ListTasksOutput { // want notLimitedReturned:"paginationObligation\\[0\\]"
+	result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{MaxResults: aws.Int32(10)})
+	return result
+}
+
+func callNotLimitedReturned(ctx context.Context, client *ecs.Client) {
+	result := notLimitedReturned(ctx, client) // want "missing pagination handling for AWS SDK List API call"
+	log.Println(result.TaskArns)
+}
+
+// Bad: The result is passed to another function
+func notLimitedPassedOn(ctx context.Context, client *ecs.Client) {
+	result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{MaxResults: aws.Int32(10)}) // want "missing pagination handling for AWS SDK List API call"
+	logTasks(result)
+}
+
+func logTasks(out *ecs.ListTasksOutput) {
+	log.Println(out.TaskArns)
+}
@@ -0,0 +1,23 @@
+package limitedignore
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+)
+
+// Test cases for calls intentionally limited to a single page (-limited-calls=ignore)
+
+// Good: Page size set in the input literal
+func limitedInPlace(ctx context.Context, client *ecs.Client) {
+	result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{MaxResults: aws.Int32(10)})
+	log.Println(result.TaskArns)
+}
+
+// Bad: No page size is set
+func notLimited(ctx context.Context, client *ecs.Client) {
+	result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	log.Println(result.TaskArns)
+}
@@ -0,0 +1,67 @@
+package test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"test/pagehelper"
+)
+
+// Test cases for functions returning List API results to their callers
+// The obligation to handle pagination moves to the caller consuming the result
+
+// listObjects returns the raw List API result without paginating
+func listObjects(client *s3.Client) (*s3.ListObjectsV2Output, error) { // want listObjects:"paginationObligation\\[0\\]"
+	out, err := client.ListObjectsV2(context.Background(), &s3.ListObjectsV2Input{})
+	return out, err
+}
+
+// listObjectsNamed returns the raw List API result through a named result
+func listObjectsNamed(client *s3.Client) (out *s3.ListObjectsV2Output, err error) { // want listObjectsNamed:"paginationObligation\\[0\\]"
+	out, err = client.ListObjectsV2(context.Background(), &s3.ListObjectsV2Input{})
+	return
+}
+
+// listObjectsWrapped returns the result of another wrapper
+func listObjectsWrapped(client *s3.Client) (*s3.ListObjectsV2Output, error) { // want listObjectsWrapped:"paginationObligation\\[0\\]"
+	result, err := listObjects(client)
+	return result, err
+}
+
+// Bad: Caller ignores NextContinuationToken of a wrapped List API result
+func badObligationCaller() {
+	client := &s3.Client{}
+	result, _ := listObjects(client) // want "Use NewListObjectsV2Paginator"
+	_ = result
+}
+
+// Bad: Caller ignores NextContinuationToken of a result returned through named results
+func badObligationNamedCaller() {
+	client := &s3.Client{}
+	result, _ := listObjectsNamed(client) // want "missing pagination handling for AWS SDK List API call"
+	_ = result
+}
+
+// Bad: Caller ignores NextContinuationToken of a result returned through two wrappers
+func badObligationWrappedCaller() {
+	client := &s3.Client{}
+	result, _ := listObjectsWrapped(client) // want "Use NewListObjectsV2Paginator"
+	_ = result
+}
+
+// Bad: Caller ignores ContinuationToken of a result returned by a wrapper in another package
+func badObligationCrossPackageCaller() {
+	client := &s3.Client{}
+	result, _ := pagehelper.FetchBuckets(client) // want "Use NewListBucketsPaginator"
+	_ = result
+}
+
+// Good: Caller handles pagination of the wrapped result
+func goodObligationCaller() {
+	client := &s3.Client{}
+	result, _ := listObjects(client)
+	if result.NextContinuationToken != nil {
+		_ = result.NextContinuationToken
+	}
+}
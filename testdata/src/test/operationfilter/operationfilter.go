@@ -0,0 +1,63 @@
+package operationfilter
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// Test cases for operation filters configured with
+// -exclude-operations=ecs.ListClusters,iam.List*,ListObjectVersionsOutput
+// -include-operations=iam.ListUsers,s3.ListObjectsV2
+// -limited-calls=ignore
+
+// Good: Excluded by service.Operation
+func excludedOperation(ctx context.Context, client *ecs.Client) {
+	result, _ := client.ListClusters(ctx, &ecs.ListClustersInput{})
+	log.Println(result.ClusterArns)
+}
+
+// Good: Excluded by a wildcard pattern
+func excludedWildcard(ctx context.Context, client *iam.Client) {
+	result, _ := client.ListRoles(ctx, &iam.ListRolesInput{})
+	log.Println(result.Roles)
+}
+
+// Good: Excluded by output type name
+func excludedOutputType(ctx context.Context, client *s3.Client) {
+	result, _ := client.ListObjectVersions(ctx, &s3.ListObjectVersionsInput{})
+	log.Println(result.Versions)
+}
+
+// Good: Excluded calls returned to the caller do not move the obligation
+func excludedReturned(ctx context.Context, client *ecs.Client) (*ecs.ListClustersOutput, error) {
+	return client.ListClusters(ctx, &ecs.ListClustersInput{})
+}
+
+// Bad: Other operations of the service are still checked
+func otherOperation(ctx context.Context, client *ecs.Client) {
+	result, _ := client.ListServices(ctx, &ecs.ListServicesInput{}) // want "missing pagination handling for AWS SDK List API call"
+	log.Println(result.ServiceArns)
+}
+
+// Bad: Included operations take precedence over excluded ones
+func includedOperation(ctx context.Context, client *iam.Client) {
+	result, _ := client.ListUsers(ctx, &iam.ListUsersInput{}) // want "missing pagination handling for AWS SDK List API call"
+	log.Println(result.Users)
+}
+
+// Bad: Included operations are reported even when intentionally limited
+func includedLimited(ctx context.Context, client *s3.Client) {
+	result, _ := client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{MaxKeys: aws.Int32(1)}) // want "missing pagination handling for AWS SDK List API call"
+	log.Println(result.Contents)
+}
+
+// Good: Other operations intentionally limited are accepted
+func limited(ctx context.Context, client *ecs.Client) {
+	result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{MaxResults: aws.Int32(1)})
+	log.Println(result.TaskArns)
+}
@@ -0,0 +1,49 @@
+package test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// Test cases for deriving paginated operations from the SDK's paginators
+// An operation with a New<Operation>Paginator paginates, whatever its token fields are named
+
+// Bad: Paginated operation with an unusual token field (NextPartNumberMarker)
+func badUnusualTokenField() {
+	client := &s3.Client{}
+	ctx := context.Background()
+	result, _ := client.ListParts(ctx, &s3.ListPartsInput{}) // want "result has NextPartNumberMarker field"
+	_ = result.Parts
+}
+
+// Good: Manual loop with the unusual token field
+func goodUnusualTokenField() {
+	client := &s3.Client{}
+	ctx := context.Background()
+	input := &s3.ListPartsInput{}
+	for {
+		result, err := client.ListParts(ctx, input)
+		if err != nil || result.NextPartNumberMarker == nil {
+			break
+		}
+		input.PartNumberMarker = result.NextPartNumberMarker
+	}
+}
+
+// Good: Marker without a paginator is not a pagination token
+func goodMarkerWithoutPaginator() {
+	client := &iam.Client{}
+	ctx := context.Background()
+	result, _ := client.GetServiceLastAccessedDetails(ctx, &iam.GetServiceLastAccessedDetailsInput{})
+	_ = result.ServicesLastAccessed
+}
+
+// Bad: Marker with a paginator is a pagination token
+func badMarkerWithPaginator() {
+	client := &iam.Client{}
+	ctx := context.Background()
+	result, _ := client.ListUsers(ctx, &iam.ListUsersInput{}) // want "result has Marker field"
+	_ = result.Users
+}
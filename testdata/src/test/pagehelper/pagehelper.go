@@ -0,0 +1,28 @@
+// Package pagehelper provides pagination helpers used to test that
+// pagination handling facts are propagated across packages.
+package pagehelper
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// NextTaskToken returns the pagination token of a ListTasks result.
+func NextTaskToken(out *ecs.ListTasksOutput) *string {
+	return out.NextToken
+}
+
+// PrintTasks consumes the tasks of a ListTasks result without paginating.
+func PrintTasks(out *ecs.ListTasksOutput) {
+	for _, task := range out.TaskArns {
+		_ = task
+	}
+}
+
+// FetchBuckets returns the raw List API result without paginating.
+func FetchBuckets(client *s3.Client) (*s3.ListBucketsOutput, error) {
+	out, err := client.ListBuckets(context.Background(), &s3.ListBucketsInput{})
+	return out, err
+}
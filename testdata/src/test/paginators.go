@@ -0,0 +1,130 @@
+package test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// Test cases for type-checked paginator recognition
+// A paginator only handles calls of the same operation with the same input, or on the
+// same client when neither input is a variable
+
+// Good: First page peeked, then the same input paginated
+func goodPaginatorSameInput() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	input := &ecs.ListTasksInput{}
+	first, _ := client.ListTasks(ctx, input)
+	_ = first.TaskArns
+	paginator := ecs.NewListTasksPaginator(client, input)
+	for paginator.HasMorePages() {
+		page, _ := paginator.NextPage(ctx)
+		_ = page.TaskArns
+	}
+}
+
+// Good: Paginator created from the same client with a new input
+func goodPaginatorSameClient() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	first, _ := client.ListTasks(ctx, &ecs.ListTasksInput{})
+	_ = first.TaskArns
+	paginator := ecs.NewListTasksPaginator(client, &ecs.ListTasksInput{})
+	for paginator.HasMorePages() {
+		_, _ = paginator.NextPage(ctx)
+	}
+}
+
+// paginatedStore holds a client in a field
+type paginatedStore struct {
+	client *ecs.Client
+}
+
+// Good: Client accessed through the same field
+func (s *paginatedStore) goodPaginatorSameField(ctx context.Context) {
+	first, _ := s.client.ListTasks(ctx, &ecs.ListTasksInput{})
+	_ = first.TaskArns
+	paginator := ecs.NewListTasksPaginator(s.client, &ecs.ListTasksInput{})
+	for paginator.HasMorePages() {
+		_, _ = paginator.NextPage(ctx)
+	}
+}
+
+// Bad: Paginator of another operation of the same service
+func badPaginatorOtherOperation() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	tasks, _ := client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	_ = tasks.TaskArns
+	paginator := ecs.NewListServicesPaginator(client, &ecs.ListServicesInput{})
+	for paginator.HasMorePages() {
+		_, _ = paginator.NextPage(ctx)
+	}
+}
+
+// Bad: Paginator of another service
+func badPaginatorOtherService() {
+	client := &ecs.Client{}
+	s3Client := &s3.Client{}
+	ctx := context.Background()
+	tasks, _ := client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	_ = tasks.TaskArns
+	paginator := s3.NewListObjectsV2Paginator(s3Client, &s3.ListObjectsV2Input{})
+	for paginator.HasMorePages() {
+		_, _ = paginator.NextPage(ctx)
+	}
+}
+
+// Bad: Only one of two calls is paginated
+func badPaginatorOneOfTwoCalls() {
+	client := &ecs.Client{}
+	other := &ecs.Client{}
+	ctx := context.Background()
+	input := &ecs.ListTasksInput{}
+	first, _ := client.ListTasks(ctx, input)
+	_ = first.TaskArns
+	second, _ := other.ListTasks(ctx, &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	_ = second.TaskArns
+	paginator := ecs.NewListTasksPaginator(client, input)
+	for paginator.HasMorePages() {
+		_, _ = paginator.NextPage(ctx)
+	}
+}
+
+// Bad: Paginator created from the same client with another input
+func badPaginatorSameClientOtherInput() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	input := &ecs.ListTasksInput{}
+	otherInput := ecs.ListTasksInput{}
+	tasks, _ := client.ListTasks(ctx, input) // want "missing pagination handling for AWS SDK List API call"
+	_ = tasks.TaskArns
+	paginator := ecs.NewListTasksPaginator(client, &otherInput)
+	for paginator.HasMorePages() {
+		_, _ = paginator.NextPage(ctx)
+	}
+}
+
+// fakePaginator is not an AWS SDK paginator
+type fakePaginator struct{}
+
+func NewFakeListTasksPaginator() *fakePaginator { return &fakePaginator{} }
+
+func (*fakePaginator) HasMorePages() bool { return false }
+
+func (*fakePaginator) NextPage(ctx context.Context) (*ecs.ListTasksOutput, error) { return nil, nil }
+
+// Bad: Paginator-like names outside of the AWS SDK do not count
+func badFakePaginator() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	tasks, _ := client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	_ = tasks.TaskArns
+	paginator := NewFakeListTasksPaginator()
+	for paginator.HasMorePages() {
+		page, _ := paginator.NextPage(ctx) // want "missing pagination handling for AWS SDK List API call"
+		_ = page.TaskArns
+	}
+}
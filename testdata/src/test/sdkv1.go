@@ -0,0 +1,127 @@
+package test
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// Test cases for AWS SDK for Go v1
+// v1 paginates with <Operation>Pages methods taking a callback instead of paginators
+
+// Bad: v1 List API call without pagination
+func badSDKv1ListUsers() {
+	svc := &iam.IAM{}
+	result, _ := svc.ListUsers(&iam.ListUsersInput{}) // want "result has Marker field\\)\nWhen there are many results, only the first page is returned. Use ListUsersPages or loop with result.Marker, feeding it back with input.Marker = result.Marker."
+	log.Println(result.Users)
+}
+
+// Bad: v1 WithContext variant without pagination
+func badSDKv1WithContext(ctx context.Context) {
+	svc := &s3.S3{}
+	input := &s3.ListObjectsV2Input{Bucket: aws.String("bucket")}
+	out, _ := svc.ListObjectsV2WithContext(ctx, input) // want "Use ListObjectsV2PagesWithContext or loop with out.NextContinuationToken, feeding it back with input.ContinuationToken = out.NextContinuationToken."
+	log.Println(out.Contents)
+}
+
+// Good: v1 manual loop
+func goodSDKv1ManualLoop() {
+	svc := &s3.S3{}
+	input := &s3.ListObjectsV2Input{Bucket: aws.String("bucket")}
+	for {
+		out, err := svc.ListObjectsV2(input)
+		if err != nil {
+			return
+		}
+		log.Println(out.Contents)
+		if !aws.BoolValue(out.IsTruncated) {
+			break
+		}
+		input.ContinuationToken = out.NextContinuationToken
+	}
+}
+
+// Good: v1 Pages method with a callback
+func goodSDKv1Pages() error {
+	svc := &iam.IAM{}
+	var users []*iam.User
+	return svc.ListUsersPages(&iam.ListUsersInput{}, func(page *iam.ListUsersOutput, lastPage bool) bool {
+		users = append(users, page.Users...)
+		return true
+	})
+}
+
+// Good: v1 PagesWithContext method whose callback stops conditionally
+func goodSDKv1PagesConditionalStop(ctx context.Context) error {
+	svc := &s3.S3{}
+	count := 0
+	return svc.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		count += len(page.Contents)
+		if count >= 1000 {
+			return false
+		}
+		return true
+	})
+}
+
+// Good: First page peeked, then the same input paginated with the Pages method
+func goodSDKv1PagesSameInput() error {
+	svc := &iam.IAM{}
+	input := &iam.ListUsersInput{}
+	first, _ := svc.ListUsers(input)
+	log.Println(first.Users)
+	return svc.ListUsersPages(input, func(page *iam.ListUsersOutput, lastPage bool) bool {
+		return true
+	})
+}
+
+// Good: First page peeked through the iface client, then the same input paginated
+func goodSDKv1IfacePagesSameInput(svc iamiface.IAMAPI) error {
+	input := &iam.ListUsersInput{}
+	first, _ := svc.ListUsers(input)
+	log.Println(first.Users)
+	return svc.ListUsersPages(input, func(page *iam.ListUsersOutput, lastPage bool) bool {
+		return true
+	})
+}
+
+// Bad: iface client call without pagination
+func badSDKv1IfaceListUsers(svc iamiface.IAMAPI) {
+	result, _ := svc.ListUsers(&iam.ListUsersInput{}) // want "Use ListUsersPages or loop with result.Marker"
+	log.Println(result.Users)
+}
+
+// Bad: Callback passed through the iface client unconditionally stops after the first page
+func badSDKv1IfacePagesReturnFalse(svc iamiface.IAMAPI) error {
+	return svc.ListUsersPages(&iam.ListUsersInput{}, func(page *iam.ListUsersOutput, lastPage bool) bool {
+		return false // want "incomplete paginator iteration: callback of ListUsersPages returns false unconditionally"
+	})
+}
+
+// Bad: Callback unconditionally stops after the first page
+func badSDKv1PagesReturnFalse() error {
+	svc := &iam.IAM{}
+	return svc.ListUsersPages(&iam.ListUsersInput{}, func(page *iam.ListUsersOutput, lastPage bool) bool {
+		log.Println(page.Users)
+		return false // want "incomplete paginator iteration: callback of ListUsersPages returns false unconditionally, so only the first page is read"
+	})
+}
+
+// Bad: PagesWithContext callback unconditionally stops after the first page
+func badSDKv1PagesWithContextReturnFalse(ctx context.Context) error {
+	svc := &s3.S3{}
+	return svc.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		return false // want "incomplete paginator iteration: callback of ListObjectsV2Pages returns false"
+	})
+}
+
+// Good: Marker without a Pages method is not a pagination token
+func goodSDKv1MarkerWithoutPages() {
+	svc := &iam.IAM{}
+	result, _ := svc.GetServiceLastAccessedDetails(&iam.GetServiceLastAccessedDetailsInput{})
+	log.Println(result.ServicesLastAccessed)
+}
@@ -0,0 +1,44 @@
+package servicefields
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+// Test cases for service-specific pagination fields configured with
+// -service-field=dynamodb:LastEvaluatedBackupArn
+
+// Bad: The configured field is not checked
+func badListBackups(ctx context.Context, client *dynamodb.Client) {
+	result, _ := client.ListBackups(ctx, &dynamodb.ListBackupsInput{}) // want "missing pagination handling for AWS SDK List API call.*LastEvaluatedBackupArn"
+	_ = result.BackupSummaries
+}
+
+// Good: Manual loop with the configured field
+func goodListBackups(ctx context.Context, client *dynamodb.Client) {
+	input := &dynamodb.ListBackupsInput{}
+	for {
+		result, err := client.ListBackups(ctx, input)
+		if err != nil {
+			return
+		}
+		_ = result.BackupSummaries
+		if result.LastEvaluatedBackupArn == nil {
+			break
+		}
+		input.ExclusiveStartBackupArn = result.LastEvaluatedBackupArn
+	}
+}
+
+// Good: Fields that are not configured are not checked
+func goodListGlobalTables(ctx context.Context, client *dynamodb.Client) {
+	result, _ := client.ListGlobalTables(ctx, &dynamodb.ListGlobalTablesInput{})
+	_ = result.GlobalTables
+}
+
+// Bad: Built-in fields of the service are still checked
+func badScan(ctx context.Context, client *dynamodb.Client) {
+	result, _ := client.Scan(ctx, &dynamodb.ScanInput{}) // want "missing pagination handling for AWS SDK List API call"
+	_ = result.Items
+}
@@ -0,0 +1,349 @@
+// Package strictloops contains test cases for the -strict-loops validation of manual pagination loops.
+package strictloops
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// Good: Complete manual loop
+func goodLoop() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	input := &ecs.ListTasksInput{}
+	for {
+		result, err := client.ListTasks(ctx, input)
+		if err != nil {
+			break
+		}
+		if result.NextToken == nil {
+			break
+		}
+		input.NextToken = result.NextToken
+	}
+}
+
+// Good: Token checked in the loop condition
+func goodLoopCondition() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	input := &ecs.ListTasksInput{}
+	var result *ecs.ListTasksOutput
+	for result == nil || result.NextToken != nil {
+		if result != nil {
+			input.NextToken = result.NextToken
+		}
+		result, _ = client.ListTasks(ctx, input)
+	}
+}
+
+// Good: Input rebuilt with the token in each iteration
+func goodRebuiltInput() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	input := &ecs.ListTasksInput{}
+	for {
+		result, _ := client.ListTasks(ctx, input)
+		if result.NextToken == nil {
+			return
+		}
+		input = &ecs.ListTasksInput{NextToken: result.NextToken}
+	}
+}
+
+// Good: NextContinuationToken fed back into ContinuationToken
+func goodContinuationToken() {
+	client := &s3.Client{}
+	ctx := context.Background()
+	input := &s3.ListObjectsV2Input{}
+	for {
+		result, _ := client.ListObjectsV2(ctx, input)
+		if result.NextContinuationToken == nil {
+			break
+		}
+		input.ContinuationToken = result.NextContinuationToken
+	}
+}
+
+// Good: LastEvaluatedKey fed back into ExclusiveStartKey
+func goodLastEvaluatedKey() {
+	client := &dynamodb.Client{}
+	ctx := context.Background()
+	input := &dynamodb.ScanInput{}
+	for {
+		result, _ := client.Scan(ctx, input)
+		if len(result.LastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+}
+
+// Good: Route53 loop stopping on IsTruncated
+func goodRoute53() {
+	client := &route53.Client{}
+	ctx := context.Background()
+	input := &route53.ListResourceRecordSetsInput{}
+	for {
+		result, _ := client.ListResourceRecordSets(ctx, input)
+		if !result.IsTruncated {
+			break
+		}
+		input.StartRecordName = result.NextRecordName
+		input.StartRecordType = result.NextRecordType
+	}
+}
+
+// Good: Token copied into a variable fed into an input literal built in the loop
+func goodTokenVariable() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	var token *string
+	for {
+		result, err := client.ListTasks(ctx, &ecs.ListTasksInput{NextToken: token})
+		if err != nil {
+			return
+		}
+		token = result.NextToken
+		if token == nil {
+			break
+		}
+	}
+}
+
+// Good: Token variable declared in the loop and fed into the input field by field
+func goodDeclaredTokenVariable() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	input := &ecs.ListTasksInput{}
+	for {
+		result, _ := client.ListTasks(ctx, input)
+		next := result.NextToken
+		if next == nil {
+			break
+		}
+		input.NextToken = next
+	}
+}
+
+// Good: S3 loop stopping on IsTruncated
+func goodIsTruncated() {
+	client := &s3.Client{}
+	ctx := context.Background()
+	input := &s3.ListObjectsV2Input{}
+	for {
+		result, _ := client.ListObjectsV2(ctx, input)
+		if !*result.IsTruncated {
+			break
+		}
+		input.ContinuationToken = result.NextContinuationToken
+	}
+}
+
+// Good: Labeled break leaving the loop from a switch
+func goodLabeledBreak(mode int) {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	input := &ecs.ListTasksInput{}
+pages:
+	for {
+		result, _ := client.ListTasks(ctx, input)
+		switch mode {
+		case 1:
+			if result.NextToken == nil {
+				break pages
+			}
+		}
+		input.NextToken = result.NextToken
+	}
+}
+
+// Good: Paginators are not validated as manual loops
+func goodPaginator() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	paginator := ecs.NewListTasksPaginator(client, &ecs.ListTasksInput{})
+	for paginator.HasMorePages() {
+		_, _ = paginator.NextPage(ctx)
+	}
+}
+
+// Bad: Token only logged, without a loop
+func badNoLoop() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "incomplete pagination loop for AWS SDK List API call: ListTasks is not called in a loop"
+	log.Printf("next token: %v", result.NextToken)
+}
+
+// Bad: Token checked but never fed back into the input
+func badNotFedBack() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	input := &ecs.ListTasksInput{}
+	for {
+		result, _ := client.ListTasks(ctx, input) // want "result.NextToken is not fed back into input.NextToken"
+		if result.NextToken == nil {
+			break
+		}
+	}
+}
+
+// Bad: Token fed back into the wrong input field
+func badWrongField() {
+	client := &s3.Client{}
+	ctx := context.Background()
+	input := &s3.ListObjectsV2Input{}
+	for {
+		result, _ := client.ListObjectsV2(ctx, input) // want "result.NextContinuationToken is not fed back into input.ContinuationToken"
+		if result.NextContinuationToken == nil {
+			break
+		}
+		input.StartAfter = result.NextContinuationToken
+	}
+}
+
+// Bad: Token fed back but the loop never stops on an empty token
+func badNoStop() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	input := &ecs.ListTasksInput{}
+	for {
+		result, _ := client.ListTasks(ctx, input) // want "loop does not stop when result.NextToken is empty"
+		input.NextToken = result.NextToken
+	}
+}
+
+// Bad: Bounded loop that only reads the token
+func badBoundedLoop() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	input := &ecs.ListTasksInput{}
+	for i := 0; i < 3; i++ {
+		result, _ := client.ListTasks(ctx, input) // want "result.NextToken is not fed back into input.NextToken" "loop does not stop when result.NextToken is empty"
+		log.Printf("next token: %v", result.NextToken)
+	}
+}
+
+// Bad: Input literal passed inline cannot receive the token
+func badInlineInput() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	for {
+		result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{}) // want "result.NextToken is not fed back into input.NextToken"
+		if result.NextToken == nil {
+			break
+		}
+	}
+}
+
+// Bad: Token variable fed into an input literal, but never checked
+func badTokenVariableNoStop() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	var token *string
+	for {
+		result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{NextToken: token}) // want "loop does not stop when result.NextToken is empty"
+		token = result.NextToken
+	}
+}
+
+// Bad: Token variable checked, but the input literal does not receive it
+func badTokenVariableNotFedBack() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	var token *string
+	for {
+		result, _ := client.ListTasks(ctx, &ecs.ListTasksInput{Cluster: token}) // want "result.NextToken is not fed back into input.NextToken"
+		token = result.NextToken
+		if token == nil {
+			break
+		}
+	}
+}
+
+// Bad: Route53 tokens fed back into each other's input fields
+func badSwappedFields() {
+	client := &route53.Client{}
+	ctx := context.Background()
+	input := &route53.ListResourceRecordSetsInput{}
+	for {
+		result, _ := client.ListResourceRecordSets(ctx, input) // want "result.NextRecordName is not fed back into input.StartRecordName"
+		if !result.IsTruncated {
+			break
+		}
+		input.StartRecordIdentifier = result.NextRecordName
+	}
+}
+
+// Bad: Break only leaves a switch in the token check
+func badSwitchBreak(mode int) {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	input := &ecs.ListTasksInput{}
+	for {
+		result, _ := client.ListTasks(ctx, input) // want "loop does not stop when result.NextToken is empty"
+		if result.NextToken == nil {
+			switch mode {
+			case 1:
+				break
+			}
+		}
+		input.NextToken = result.NextToken
+	}
+}
+
+// Bad: Break only leaves a select in the token check
+func badSelectBreak(done chan struct{}) {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	input := &ecs.ListTasksInput{}
+	for {
+		result, _ := client.ListTasks(ctx, input) // want "loop does not stop when result.NextToken is empty"
+		if result.NextToken == nil {
+			select {
+			case <-done:
+				break
+			default:
+			}
+		}
+		input.NextToken = result.NextToken
+	}
+}
+
+// Bad: Token checked in an inner loop, whose break only leaves the inner loop
+func badInnerLoopBreak() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	input := &ecs.ListTasksInput{}
+	for {
+		result, _ := client.ListTasks(ctx, input) // want "loop does not stop when result.NextToken is empty"
+		for range 3 {
+			if result.NextToken == nil {
+				break
+			}
+		}
+		input.NextToken = result.NextToken
+	}
+}
+
+// Bad: Return in a function literal does not leave the loop
+func badFuncLitReturn() {
+	client := &ecs.Client{}
+	ctx := context.Background()
+	input := &ecs.ListTasksInput{}
+	for {
+		result, _ := client.ListTasks(ctx, input) // want "loop does not stop when result.NextToken is empty"
+		if result.NextToken == nil {
+			func() {
+				return
+			}()
+		}
+		input.NextToken = result.NextToken
+	}
+}
@@ -0,0 +1,204 @@
+// Package stricttokenuse contains test cases for -strict-token-use, which only counts meaningful reads of pagination tokens.
+package stricttokenuse
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+)
+
+// Bad: Token only logged
+func badLogged() {
+	client := &ecs.Client{}
+	result, _ := client.ListTasks(context.Background(), &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	log.Printf("next token: %v", result.NextToken)
+}
+
+// Bad: Token only printed through aws.ToString
+func badPrinted() {
+	client := &ecs.Client{}
+	result, _ := client.ListTasks(context.Background(), &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	fmt.Println("next token:", aws.ToString(result.NextToken))
+}
+
+// Bad: Token only formatted into a message
+func badFormatted() string {
+	client := &ecs.Client{}
+	result, _ := client.ListTasks(context.Background(), &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	return fmt.Sprintf("next token: %v", result.NextToken)
+}
+
+// Bad: Token only logged with a structured logger
+func badStructuredLog(logger *slog.Logger) {
+	client := &ecs.Client{}
+	result, _ := client.ListTasks(context.Background(), &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	logger.Info("listed tasks", "nextToken", result.NextToken)
+}
+
+// Bad: Token only assigned to the blank identifier
+func badBlank() {
+	client := &ecs.Client{}
+	result, _ := client.ListTasks(context.Background(), &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	_ = result.NextToken
+}
+
+// Bad: Token only passed to a helper that logs it
+func badLoggingHelper() {
+	client := &ecs.Client{}
+	result, _ := client.ListTasks(context.Background(), &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	logTasks(result)
+}
+
+func logTasks(output *ecs.ListTasksOutput) {
+	log.Println(output.TaskArns, output.NextToken)
+}
+
+// Bad: Token only logged through a local variable
+func badLaunderedVariable() {
+	client := &ecs.Client{}
+	result, _ := client.ListTasks(context.Background(), &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	tok := result.NextToken
+	log.Printf("%v", tok)
+}
+
+// Bad: Token only logged through a chain of local variables
+func badLaunderedChain() {
+	client := &ecs.Client{}
+	result, _ := client.ListTasks(context.Background(), &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	var tok = result.NextToken
+	next := tok
+	log.Printf("%v", next)
+}
+
+// Good: Token variable checked to control the flow
+func goodTokenVariable() {
+	client := &ecs.Client{}
+	input := &ecs.ListTasksInput{}
+	for {
+		result, _ := client.ListTasks(context.Background(), input)
+		next := result.NextToken
+		if next == nil {
+			break
+		}
+		input.NextToken = next
+	}
+}
+
+// Good: Nil check of the token controlling the flow
+func goodNilCheck() {
+	client := &ecs.Client{}
+	input := &ecs.ListTasksInput{}
+	for {
+		result, _ := client.ListTasks(context.Background(), input)
+		log.Printf("next token: %v", result.NextToken)
+		if result.NextToken == nil {
+			break
+		}
+		input.NextToken = result.NextToken
+	}
+}
+
+// Good: Empty check of the token through aws.ToString
+func goodEmptyCheck() {
+	client := &ecs.Client{}
+	input := &ecs.ListTasksInput{}
+	for {
+		result, _ := client.ListTasks(context.Background(), input)
+		if aws.ToString(result.NextToken) == "" {
+			return
+		}
+		input = &ecs.ListTasksInput{}
+	}
+}
+
+// Good: Token assigned into the request input
+func goodInputAssignment() {
+	client := &ecs.Client{}
+	input := &ecs.ListTasksInput{}
+	result, _ := client.ListTasks(context.Background(), input)
+	input.NextToken = result.NextToken
+}
+
+// Good: Token set in a new request input
+func goodInputLiteral() *ecs.ListTasksInput {
+	client := &ecs.Client{}
+	result, _ := client.ListTasks(context.Background(), &ecs.ListTasksInput{})
+	return &ecs.ListTasksInput{NextToken: result.NextToken}
+}
+
+// Good: Token returned to the caller
+func goodReturned() *string {
+	client := &ecs.Client{}
+	result, _ := client.ListTasks(context.Background(), &ecs.ListTasksInput{})
+	return result.NextToken
+}
+
+// Good: Token passed to a helper that checks it
+func goodCheckingHelper() {
+	client := &ecs.Client{}
+	result, _ := client.ListTasks(context.Background(), &ecs.ListTasksInput{})
+	checkTasks(result)
+}
+
+func checkTasks(output *ecs.ListTasksOutput) { // want checkTasks:"handlesPagination\\[0\\]"
+	if output.NextToken != nil {
+		log.Println("more tasks")
+	}
+}
+
+// Good: Route53 IsTruncated controlling the loop
+func goodRoute53() {
+	client := &route53.Client{}
+	input := &route53.ListResourceRecordSetsInput{}
+	for {
+		result, _ := client.ListResourceRecordSets(context.Background(), input)
+		if !result.IsTruncated {
+			break
+		}
+		input.StartRecordName = result.NextRecordName
+	}
+}
+
+// Bad: Route53 IsTruncated only logged
+func badRoute53() {
+	client := &route53.Client{}
+	result, _ := client.ListResourceRecordSets(context.Background(), &route53.ListResourceRecordSetsInput{}) // want "missing pagination handling for AWS SDK List API call"
+	log.Printf("truncated: %t", result.IsTruncated)
+}
+
+type logger interface {
+	Infof(format string, args ...any)
+}
+
+// Bad: Token only logged with a method of another logger
+func badOtherLogger(l logger) {
+	client := &ecs.Client{}
+	result, _ := client.ListTasks(context.Background(), &ecs.ListTasksInput{}) // want "missing pagination handling for AWS SDK List API call"
+	l.Infof("next token: %v", result.NextToken)
+}
+
+type validator struct{}
+
+func (validator) ErrorIfEmpty(token *string) error {
+	if token == nil {
+		return fmt.Errorf("empty token")
+	}
+	return nil
+}
+
+type store struct{}
+
+func (store) LogicalNext(token *string) {}
+
+// Good: Token passed to methods named like logging methods that do not log
+func goodNotLogging(v validator, s store) error {
+	client := &ecs.Client{}
+	result, _ := client.ListTasks(context.Background(), &ecs.ListTasksInput{})
+	s.LogicalNext(result.NextToken)
+	return v.ErrorIfEmpty(result.NextToken)
+}
@@ -0,0 +1,191 @@
+package awspagination
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// formattingPackages are the packages whose functions and methods only format or log
+// their arguments, so that passing a pagination token to them is not a use of it.
+var formattingPackages = []string{
+	"fmt",
+	"log",
+	"log/slog",
+	"github.com/sirupsen/logrus",
+	"go.uber.org/zap",
+	"github.com/rs/zerolog",
+	"github.com/rs/zerolog/log",
+	"github.com/golang/glog",
+	"k8s.io/klog/v2",
+}
+
+// loggingMethodPrefixes are the name prefixes of the methods of other loggers (e.g., a
+// Logger interface or testing.T). Only methods taking their arguments as ...any count
+// (e.g., Infof(format string, args ...any)), so that methods such as
+// ErrorIfEmpty(token *string) are not taken for logging.
+var loggingMethodPrefixes = []string{"Print", "Debug", "Info", "Warn", "Error", "Fatal", "Panic", "Trace", "Log"}
+
+// meaningfulTokenRead checks if the read of a pagination token sel, whose ancestors in
+// body are stack (innermost last), is a meaningful use of the token for -strict-token-use.
+// A read is meaningful if it controls the flow or stores the token:
+//   - the condition of an if or for statement, or a switch tag or case
+//     (e.g., if out.NextToken == nil, for out.IsTruncated)
+//   - the value of an assignment into a field, element or pointer
+//     (e.g., input.NextToken = out.NextToken)
+//   - a field or element of a composite literal (e.g., &ListTasksInput{NextToken: out.NextToken})
+//   - a returned or sent value
+//   - an argument of a function call other than formatting or logging
+//
+// A token assigned or declared into a local variable (e.g., next := out.NextToken) is
+// followed to the uses of that variable in body, which are checked by the same rules.
+// Reads that are only formatted or logged (e.g., log.Printf("next: %v", out.NextToken)),
+// even through other calls (e.g., aws.ToString) or local variables, and reads assigned to
+// the blank identifier are not meaningful.
+func meaningfulTokenRead(pass *analysis.Pass, body ast.Node, sel *ast.SelectorExpr, stack []ast.Node) bool {
+	return meaningfulRead(pass, body, sel, stack, make(map[types.Object]bool))
+}
+
+// meaningfulRead checks if the read of a token expr, whose ancestors in body are stack,
+// is meaningful, see meaningfulTokenRead.
+// followed holds the local variables already followed, so that variables assigned to
+// each other are followed once.
+func meaningfulRead(pass *analysis.Pass, body ast.Node, expr ast.Expr, stack []ast.Node, followed map[types.Object]bool) bool {
+	// passed tells if the token is passed to a call, which may use it
+	passed := false
+
+	var child ast.Node = expr
+	for _, node := range slices.Backward(stack) {
+		switch node := node.(type) {
+		case *ast.CallExpr:
+			if isFormattingCall(pass, node) {
+				return false
+			}
+			passed = true
+
+		case *ast.IfStmt:
+			return child == node.Cond || passed
+		case *ast.ForStmt:
+			return child == node.Cond || passed
+		case *ast.SwitchStmt:
+			return child == node.Tag || passed
+		case *ast.CaseClause:
+			return true
+
+		case *ast.AssignStmt:
+			i := slices.Index(node.Rhs, child.(ast.Expr))
+			if i < 0 {
+				// The token is assigned to, not read
+				return passed
+			}
+			if len(node.Lhs) == len(node.Rhs) {
+				return storesToken(pass, body, node.Lhs[i], passed, followed)
+			}
+			return !slices.ContainsFunc(node.Lhs, isBlank)
+		case *ast.ValueSpec:
+			if i := slices.Index(node.Values, child.(ast.Expr)); i >= 0 && len(node.Names) == len(node.Values) {
+				return storesToken(pass, body, node.Names[i], passed, followed)
+			}
+			return slices.ContainsFunc(node.Names, func(name *ast.Ident) bool { return name.Name != "_" })
+
+		case *ast.CompositeLit, *ast.KeyValueExpr, *ast.ReturnStmt, *ast.SendStmt:
+			return true
+
+		case ast.Stmt:
+			return passed
+		}
+		child = node
+	}
+	return passed
+}
+
+// storesToken checks if assigning a token to lhs is a meaningful use of the token.
+// Stores into a field, an element, through a pointer or into a package-level variable
+// are meaningful, while stores into a local variable are meaningful if the token was
+// passed to a call on the way or if the variable is used meaningfully in body.
+func storesToken(pass *analysis.Pass, body ast.Node, lhs ast.Expr, passed bool, followed map[types.Object]bool) bool {
+	ident, ok := lhs.(*ast.Ident)
+	if !ok {
+		return true
+	}
+	if ident.Name == "_" {
+		return false
+	}
+
+	v, ok := pass.TypesInfo.ObjectOf(ident).(*types.Var)
+	if !ok {
+		return passed
+	}
+	if v.Pkg() != nil && v.Parent() == v.Pkg().Scope() {
+		return true
+	}
+	return passed || meaningfulVariableUse(pass, body, v, followed)
+}
+
+// meaningfulVariableUse checks if the local variable v holding a token is read meaningfully
+// in body, see meaningfulTokenRead.
+func meaningfulVariableUse(pass *analysis.Pass, body ast.Node, v *types.Var, followed map[types.Object]bool) bool {
+	if followed[v] {
+		return false
+	}
+	followed[v] = true
+
+	meaningful := false
+	var stack []ast.Node
+	ast.Inspect(body, func(node ast.Node) bool {
+		if meaningful {
+			return false
+		}
+		if node == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		if ident, ok := node.(*ast.Ident); ok && pass.TypesInfo.Uses[ident] == v && meaningfulRead(pass, body, ident, stack, followed) {
+			meaningful = true
+		}
+		stack = append(stack, node)
+		return true
+	})
+	return meaningful
+}
+
+// isFormattingCall checks if callExpr calls a function or method that only formats or
+// logs its arguments, see formattingPackages and loggingMethodPrefixes.
+func isFormattingCall(pass *analysis.Pass, callExpr *ast.CallExpr) bool {
+	switch callee := typeutil.Callee(pass.TypesInfo, callExpr).(type) {
+	case *types.Builtin:
+		return callee.Name() == "print" || callee.Name() == "println"
+	case *types.Func:
+		if callee.Pkg() != nil && slices.Contains(formattingPackages, callee.Pkg().Path()) {
+			return true
+		}
+		sig := callee.Signature()
+		if sig.Recv() == nil || !variadicAny(sig) {
+			return false
+		}
+		return slices.ContainsFunc(loggingMethodPrefixes, func(prefix string) bool {
+			return strings.HasPrefix(callee.Name(), prefix)
+		})
+	}
+	return false
+}
+
+// variadicAny checks if the last parameter of sig is ...any (or ...interface{}).
+func variadicAny(sig *types.Signature) bool {
+	if !sig.Variadic() {
+		return false
+	}
+	last := sig.Params().At(sig.Params().Len() - 1).Type().(*types.Slice)
+	iface, ok := last.Elem().Underlying().(*types.Interface)
+	return ok && iface.Empty()
+}
+
+// isBlank checks if expr is the blank identifier.
+func isBlank(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "_"
+}
@@ -0,0 +1,158 @@
+package awspagination
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// resultValue tracks the references to the value that a List API call assigns to a variable.
+// Identifiers are matched through the type checker's object identity rather than by name,
+// so shadowed or unrelated variables with the same name are never mistaken for the result.
+//
+// A reference refers to the value if it reads the variable (or one of its aliases, such as
+// r in r := result) and is not preceded by another assignment to the variable since the call
+// that dominates it (see reassignment). References before the call only count when they are
+// in a loop around the call, since the value then flows back to them in the next iteration
+// (e.g., a loop condition).
+type resultValue struct {
+	pass *analysis.Pass
+
+	// objects holds the variable and its aliases.
+	objects map[types.Object]bool
+
+	// assignPos is the position of the assignment of the value.
+	// NoPos disables position checks, for variables whose references may be located
+	// in other files (e.g., package-level variables).
+	assignPos token.Pos
+
+	// reassignments holds the other assignments to the variable.
+	reassignments []reassignment
+
+	// loops holds the loops enclosing the assignment.
+	loops []ast.Node
+}
+
+// reassignment is another assignment to the variable of a resultValue.
+type reassignment struct {
+	// pos is the position of the assignment statement.
+	pos token.Pos
+
+	// block is the innermost block (or case clause) enclosing the statement.
+	// The assignment only hides the value from references following it in the same block
+	// or in blocks nested in it, which approximates dominance without a control flow
+	// graph: assignments in a branch (e.g., if cond { result = nil }) do not hide later
+	// references, since the branch may not be taken, and references earlier in a loop
+	// body still refer to the value in the first iteration.
+	// Jumps out of the block (e.g., goto) are not taken into account.
+	block ast.Node
+}
+
+// newResultValue creates a resultValue for the value assigned to obj at assignPos,
+// collecting aliases, reassignments and enclosing loops within body.
+func newResultValue(pass *analysis.Pass, body ast.Node, obj types.Object, assignPos token.Pos) *resultValue {
+	value := &resultValue{
+		pass:      pass,
+		objects:   map[types.Object]bool{obj: true},
+		assignPos: assignPos,
+	}
+	if !assignPos.IsValid() {
+		return value
+	}
+
+	// Reassignments and loops are collected first, as they decide which aliases are valid.
+	// stack holds the ancestors of the visited node, innermost last.
+	var stack []ast.Node
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case nil:
+			stack = stack[:len(stack)-1]
+			return true
+		case *ast.ForStmt, *ast.RangeStmt:
+			if node.Pos() <= assignPos && assignPos < node.End() {
+				value.loops = append(value.loops, node)
+			}
+		case *ast.AssignStmt:
+			// Skip the statement of the assignment itself
+			if node.Pos() > assignPos || assignPos >= node.End() {
+				for _, lhs := range node.Lhs {
+					if ident, ok := lhs.(*ast.Ident); ok && pass.TypesInfo.ObjectOf(ident) == obj {
+						value.reassignments = append(value.reassignments, reassignment{pos: node.Pos(), block: innermostBlock(body, stack)})
+					}
+				}
+			}
+		}
+		stack = append(stack, node)
+		return true
+	})
+
+	// Aliases are collected in source order, so aliases of aliases are found as well
+	ast.Inspect(body, func(node ast.Node) bool {
+		assignStmt, ok := node.(*ast.AssignStmt)
+		if !ok || len(assignStmt.Lhs) != len(assignStmt.Rhs) {
+			return true
+		}
+		for i, rhs := range assignStmt.Rhs {
+			if !value.refersTo(rhs) {
+				continue
+			}
+			if ident, ok := assignStmt.Lhs[i].(*ast.Ident); ok && ident.Name != "_" {
+				if alias := pass.TypesInfo.ObjectOf(ident); alias != nil {
+					value.objects[alias] = true
+				}
+			}
+		}
+		return true
+	})
+
+	return value
+}
+
+// innermostBlock returns the innermost block or case clause in stack, or body if none.
+func innermostBlock(body ast.Node, stack []ast.Node) ast.Node {
+	for _, node := range slices.Backward(stack) {
+		switch node.(type) {
+		case *ast.BlockStmt, *ast.CaseClause, *ast.CommClause:
+			return node
+		}
+	}
+	return body
+}
+
+// refersTo reports whether expr is a reference to the tracked value.
+func (v *resultValue) refersTo(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	if !ok || !v.objects[v.pass.TypesInfo.Uses[ident]] {
+		return false
+	}
+	if !v.assignPos.IsValid() {
+		return true
+	}
+
+	pos := ident.Pos()
+	if pos < v.assignPos {
+		// Loop-carried reference, e.g., `for result == nil || result.NextToken != nil`
+		return v.inLoop(pos)
+	}
+
+	// The variable holds another value after a reassignment dominating the reference
+	for _, r := range v.reassignments {
+		if v.assignPos < r.pos && r.pos < pos && r.block.Pos() <= pos && pos < r.block.End() {
+			return false
+		}
+	}
+	return true
+}
+
+// inLoop reports whether pos is inside one of the loops enclosing the assignment.
+func (v *resultValue) inLoop(pos token.Pos) bool {
+	for _, loop := range v.loops {
+		if loop.Pos() <= pos && pos < loop.End() {
+			return true
+		}
+	}
+	return false
+}